@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show the effective project configuration",
+	Long: `Show the configuration in effect: the project's .vibe.yaml merged over the
+built-in defaults.
+
+.vibe.yaml is looked up from the current directory towards the filesystem
+root. Paths in it are relative to the file's directory.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cfg.Path != "" {
+			fmt.Printf("# Loaded from %s\n", cfg.Path)
+		} else {
+			fmt.Println("# No .vibe.yaml found, showing defaults")
+		}
+
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(cfg); err != nil {
+			return fmt.Errorf("failed to render config: %w", err)
+		}
+		return enc.Close()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(configCmd)
+}
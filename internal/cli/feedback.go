@@ -5,15 +5,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/output"
 )
 
 var (
 	feedbackSEP  string
 	feedbackFile string
+
+	feedbackListFormat string
 )
 
 var feedbackCmd = &cobra.Command{
@@ -85,17 +89,40 @@ Examples:
 }
 
 var feedbackListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "View recorded feedback",
+	Use:          "list",
+	Short:        "View recorded feedback",
+	Annotations:  supportsOutput(),
+	SilenceUsage: true,
+	Long: `View recorded feedback.
+
+--format renders each entry with a Go template instead, e.g.
+  vibe feedback list --format '{{.Time}}\t{{.SEP}}\t{{.Message}}'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		tmpl, err := parseFormat(feedbackListFormat)
+		if err != nil {
+			return err
+		}
+
 		content, err := os.ReadFile(feedbackFile)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read feedback: %w", err)
+		}
+
+		if structuredOutput() {
+			return printStructured(output.NewFeedbackList(parseFeedback(string(content))))
+		}
+		if tmpl != nil {
+			var rows []interface{}
+			for _, entry := range parseFeedback(string(content)) {
+				rows = append(rows, entry)
+			}
+			return printFormatted(tmpl, rows)
+		}
+
 		if os.IsNotExist(err) {
 			fmt.Println("No feedback recorded yet.")
 			return nil
 		}
-		if err != nil {
-			return fmt.Errorf("failed to read feedback: %w", err)
-		}
 
 		if len(content) == 0 {
 			fmt.Println("No feedback recorded yet.")
@@ -109,6 +136,30 @@ var feedbackListCmd = &cobra.Command{
 	},
 }
 
+// feedbackEntryRe matches the first line of a feedback log entry
+var feedbackEntryRe = regexp.MustCompile(`^\[(\d{4}-\d{2}-\d{2} \d{2}:\d{2})\] (?:SEP-(\d+): )?(.*)$`)
+
+// parseFeedback splits the feedback log into entries. Lines that do not
+// start a new entry continue the message of the previous one.
+func parseFeedback(content string) []output.FeedbackEntry {
+	var entries []output.FeedbackEntry
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		if m := feedbackEntryRe.FindStringSubmatch(line); m != nil {
+			entries = append(entries, output.FeedbackEntry{Time: m[1], SEP: m[2], Message: m[3]})
+			continue
+		}
+		if len(entries) == 0 {
+			if line == "" {
+				continue
+			}
+			entries = append(entries, output.FeedbackEntry{Message: line})
+			continue
+		}
+		entries[len(entries)-1].Message += "\n" + line
+	}
+	return entries
+}
+
 var feedbackClearCmd = &cobra.Command{
 	Use:   "clear",
 	Short: "Clear all feedback (after reviewing)",
@@ -125,6 +176,7 @@ func init() {
 	RootCmd.AddCommand(feedbackCmd)
 	feedbackCmd.AddCommand(feedbackListCmd)
 	feedbackCmd.AddCommand(feedbackClearCmd)
+	addFormatFlag(feedbackListCmd, &feedbackListFormat, "entry")
 
 	feedbackCmd.Flags().StringVar(&feedbackSEP, "sep", "", "Link feedback to a specific SEP number")
 	feedbackCmd.PersistentFlags().StringVar(&feedbackFile, "file", "docs/feedback.log", "Feedback log file")
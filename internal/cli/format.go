@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+// formatEscapes lets shells pass tabs and newlines as \t and \n
+var formatEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+// formatFuncs are the functions available to --format templates
+var formatFuncs = template.FuncMap{
+	"join":     func(list []string, sep string) string { return strings.Join(list, sep) },
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"truncate": truncate,
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// addFormatFlag registers --format on a list command whose rows are what,
+// storing the template in text
+func addFormatFlag(cmd *cobra.Command, text *string, what string) {
+	cmd.Flags().StringVar(text, "format", "",
+		`Render each `+what+` with a Go template, e.g. '{{.Number}}\t{{.Title}}'`)
+}
+
+// parseFormat compiles a --format template, or returns nil if it is empty
+func parseFormat(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	if structuredOutput() {
+		return nil, fmt.Errorf("--format cannot be combined with --output %s", outputFormat)
+	}
+
+	tmpl, err := template.New("format").Funcs(formatFuncs).Parse(formatEscapes.Replace(text))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --format template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// printFormatted renders tmpl once per row, each on its own line
+func printFormatted(tmpl *template.Template, rows []interface{}) error {
+	w := bufio.NewWriter(os.Stdout)
+	for _, row := range rows {
+		if err := renderRow(w, tmpl, row); err != nil {
+			w.Flush()
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func renderRow(w io.Writer, tmpl *template.Template, row interface{}) error {
+	if err := tmpl.Execute(w, row); err != nil {
+		return fmt.Errorf("failed to render --format: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// sepRow is what --format renders for a SEP: all fields and methods of
+// sep.SEP plus computed columns
+type sepRow struct {
+	*sep.SEP
+	Conflicts []string // numbers of the SEPs whose areas overlap this one's
+}
+
+// Stale lists why the SEP's claim looks abandoned. It reads git history,
+// so it is only computed when a template uses it.
+func (r sepRow) Stale() []string {
+	return append([]string{}, staleReasons(r.SEP, cfg.Claims.StaleDays)...)
+}
+
+// sepRows builds the --format rows for seps, with conflicts as found among
+// them and possibly other SEPs
+func sepRows(seps []*sep.SEP, conflicts []sep.Conflict) []interface{} {
+	conflictMap := conflictNumbers(conflicts)
+	rows := make([]interface{}, 0, len(seps))
+	for _, s := range seps {
+		rows = append(rows, sepRow{SEP: s, Conflicts: append([]string{}, conflictMap[s.Number]...)})
+	}
+	return rows
+}
+
+// conflictNumbers maps each SEP number to the numbers it conflicts with
+func conflictNumbers(conflicts []sep.Conflict) map[string][]string {
+	numbers := make(map[string][]string)
+	for _, c := range conflicts {
+		numbers[c.SEP1.Number] = append(numbers[c.SEP1.Number], c.SEP2.Number)
+		numbers[c.SEP2.Number] = append(numbers[c.SEP2.Number], c.SEP1.Number)
+	}
+	return numbers
+}
@@ -0,0 +1,341 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+// repoFiles lists the files of the current git repository relative to its
+// root: tracked files plus untracked ones not excluded by .gitignore
+func repoFiles() ([]string, error) {
+	out, err := exec.Command("git", "ls-files", "--cached", "--others", "--exclude-standard",
+		"--full-name", "-z", "--", ":/").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files failed: %w", gitError(err))
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, f := range bytes.Split(out, []byte{0}) {
+		// Files with merge conflicts are listed once per stage
+		if name := string(f); name != "" && !seen[name] {
+			seen[name] = true
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+// gitError includes git's stderr output in the error, if any
+func gitError(err error) error {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if msg := strings.TrimSpace(string(exitErr.Stderr)); msg != "" {
+			return fmt.Errorf("%s", msg)
+		}
+	}
+	return err
+}
+
+// sepCommitFiles returns the files changed by commits whose subject starts
+// with "SEP-NNNN:", relative to the repository root, sorted
+func sepCommitFiles(number string) ([]string, error) {
+	commits, err := sepCommits(number, "--no-renames")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, c := range commits {
+		for _, name := range c.Files {
+			if !seen[name] {
+				seen[name] = true
+				files = append(files, name)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// sepCommitPrefix is the subject prefix of the commits of a SEP
+func sepCommitPrefix(number string) string {
+	return "SEP-" + number + ":"
+}
+
+// sepCommits returns the commits whose subject starts with "SEP-NNNN:",
+// newest first. git's --grep also matches body lines, so the subjects are
+// checked again.
+func sepCommits(number string, args ...string) ([]gitCommit, error) {
+	prefix := sepCommitPrefix(number)
+	commits, err := gitLog(append([]string{"--grep=^" + prefix}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []gitCommit
+	for _, c := range commits {
+		if strings.HasPrefix(c.Subject, prefix) {
+			matched = append(matched, c)
+		}
+	}
+	return matched, nil
+}
+
+// repoRoot returns the top-level directory of the current git repository
+// or worktree, with symlinks resolved
+func repoRoot() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", fmt.Errorf("not a git repository: %w", gitError(err))
+	}
+	return filepath.EvalSymlinks(strings.TrimSpace(string(out)))
+}
+
+// repoPath converts a path on disk into a path relative to the root of the
+// current git repository, as used by areas and git output
+func repoPath(path string) (string, error) {
+	root, err := repoRoot()
+	if err != nil {
+		return "", err
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// changedFiles returns the files with staged changes and, unless
+// stagedOnly is set, unstaged changes, relative to the repository root
+func changedFiles(stagedOnly bool) ([]string, error) {
+	runs := [][]string{{"diff", "--cached", "--name-only", "-z"}}
+	if !stagedOnly {
+		runs = append(runs, []string{"diff", "--name-only", "-z"})
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, args := range runs {
+		out, err := exec.Command("git", args...).Output()
+		if err != nil {
+			return nil, fmt.Errorf("git diff failed: %w", gitError(err))
+		}
+		for _, f := range bytes.Split(out, []byte{0}) {
+			if name := string(f); name != "" && !seen[name] {
+				seen[name] = true
+				files = append(files, name)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// runGit runs a git command, passing its output through to the terminal
+func runGit(args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// gitOutput runs a git command and returns its trimmed standard output
+func gitOutput(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", gitError(err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// gitUpstream returns the upstream of the current branch, e.g.
+// "origin/main", or "" if it has none
+func gitUpstream() string {
+	upstream, err := gitOutput("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	if err != nil {
+		return ""
+	}
+	return upstream
+}
+
+// gitPush pushes the current branch. rejected reports whether the remote
+// refused it because it has commits the local branch lacks, or because
+// another push updated the branch at the same moment.
+func gitPush() (rejected bool, err error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "push")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	if err := cmd.Run(); err != nil {
+		msg := stderr.String()
+		for _, reason := range []string{"[rejected]", "non-fast-forward", "fetch first", "cannot lock ref"} {
+			if strings.Contains(msg, reason) {
+				rejected = true
+			}
+		}
+		return rejected, err
+	}
+	return false, nil
+}
+
+// lastSEPCommit returns the commit time of the latest commit whose subject
+// starts with "SEP-NNNN:", lease renewals aside, or the zero time if there
+// is none. Local and remote-tracking branches are searched too, so work on
+// a SEP branch or in a worktree counts before it is merged.
+func lastSEPCommit(number string) (time.Time, error) {
+	if exec.Command("git", "rev-parse", "--verify", "--quiet", "HEAD").Run() != nil {
+		return time.Time{}, nil
+	}
+
+	prefix := sepCommitPrefix(number)
+	out, err := gitOutput("log", "HEAD", "--branches", "--remotes", "--format=%cI%x1f%s", "--grep=^"+prefix)
+	if err != nil || out == "" {
+		return time.Time{}, err
+	}
+
+	// Several branches are walked at once, so take the latest date rather
+	// than the first line
+	var last time.Time
+	for _, line := range strings.Split(out, "\n") {
+		date, subject, _ := strings.Cut(line, "\x1f")
+		if !strings.HasPrefix(subject, prefix) || sep.IsRenewalSubject(subject) {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	return last, nil
+}
+
+// currentBranch returns the name of the checked-out branch, or "" when
+// HEAD is detached
+func currentBranch() string {
+	branch, err := gitOutput("symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return branch
+}
+
+// refExists reports whether a fully qualified ref, e.g. "refs/heads/main",
+// exists
+func refExists(ref string) bool {
+	return exec.Command("git", "rev-parse", "--verify", "--quiet", ref).Run() == nil
+}
+
+// hasRemote reports whether the repository has a remote of that name
+func hasRemote(name string) bool {
+	return exec.Command("git", "remote", "get-url", name).Run() == nil
+}
+
+// gitCommit is a commit as listed by gitLog
+type gitCommit struct {
+	Hash    string
+	Author  string
+	Date    string // YYYY-MM-DD
+	Subject string
+	Files   []string // changed files, relative to the repository root
+}
+
+// gitLog runs git log with the given arguments and returns the commits,
+// newest first, with the files each one changed
+func gitLog(args ...string) ([]gitCommit, error) {
+	if exec.Command("git", "rev-parse", "--verify", "--quiet", "HEAD").Run() != nil {
+		return nil, nil
+	}
+
+	gitArgs := append([]string{"-c", "core.quotePath=false", "log",
+		"--format=%x1e%H%x1f%an%x1f%as%x1f%s", "--name-only"}, args...)
+	out, err := exec.Command("git", gitArgs...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", gitError(err))
+	}
+
+	var commits []gitCommit
+	for _, record := range strings.Split(string(out), "\x1e") {
+		if record == "" {
+			continue
+		}
+		lines := strings.Split(record, "\n")
+		fields := strings.SplitN(lines[0], "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		c := gitCommit{Hash: fields[0], Author: fields[1], Date: fields[2], Subject: fields[3]}
+		for _, f := range lines[1:] {
+			if f = strings.TrimSpace(f); f != "" {
+				c.Files = append(c.Files, f)
+			}
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+// withAllFiles replaces the file lists of commits, which a pathspec given
+// to gitLog limits to the matching files, with every file each one changed
+func withAllFiles(commits []gitCommit) ([]gitCommit, error) {
+	if len(commits) == 0 {
+		return commits, nil
+	}
+
+	hashes := []string{"--no-walk=unsorted"}
+	for _, c := range commits {
+		hashes = append(hashes, c.Hash)
+	}
+	full, err := gitLog(hashes...)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]string, len(full))
+	for _, c := range full {
+		files[c.Hash] = c.Files
+	}
+	for i := range commits {
+		commits[i].Files = files[commits[i].Hash]
+	}
+	return commits, nil
+}
+
+// commitOrder maps every commit reachable from HEAD to its position in
+// topological order, oldest first
+func commitOrder() (map[string]int, error) {
+	order := make(map[string]int)
+	if exec.Command("git", "rev-parse", "--verify", "--quiet", "HEAD").Run() != nil {
+		return order, nil
+	}
+
+	out, err := gitOutput("rev-list", "--topo-order", "--reverse", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("git rev-list failed: %w", err)
+	}
+	for i, hash := range strings.Fields(out) {
+		order[hash] = i
+	}
+	return order, nil
+}
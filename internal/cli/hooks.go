@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/config"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+// hookMarker identifies hooks written by 'vibe hooks install'
+const hookMarker = "# Installed by 'vibe hooks install'"
+
+// commitMsgHook is the commit-msg hook script
+const commitMsgHook = `#!/bin/sh
+` + hookMarker + `; checks SEP-XXXX: commit prefixes.
+if ! command -v vibe >/dev/null 2>&1; then
+	echo "vibe not found in PATH; skipping SEP commit checks" >&2
+	exit 0
+fi
+exec vibe hooks commit-msg "$1"
+`
+
+var hooksInstallForce bool
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manage git hooks that enforce SEP conventions",
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the commit-msg hook",
+	Long: `Install a git commit-msg hook that runs 'vibe hooks commit-msg' on every
+commit. An existing commit-msg hook not written by vibe is left alone
+unless --force is given.
+
+How strict the hook is comes from the hooks section of .vibe.yaml.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := gitOutput("rev-parse", "--git-path", "hooks")
+		if err != nil {
+			return fmt.Errorf("not a git repository: %w", err)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create hooks directory: %w", err)
+		}
+
+		path := filepath.Join(dir, "commit-msg")
+		if existing, err := os.ReadFile(path); err == nil && !strings.Contains(string(existing), hookMarker) && !hooksInstallForce {
+			return fmt.Errorf("%s already exists and was not installed by vibe; use --force to replace it", path)
+		}
+
+		if err := os.WriteFile(path, []byte(commitMsgHook), 0755); err != nil {
+			return fmt.Errorf("failed to write hook: %w", err)
+		}
+
+		fmt.Printf("✓ Installed %s\n", path)
+		fmt.Printf("  commit_msg: %s, require_prefix: %s (see hooks in %s)\n", cfg.Hooks.CommitMsg, cfg.Hooks.RequirePrefix, config.FileName)
+		return nil
+	},
+}
+
+var hooksCommitMsgCmd = &cobra.Command{
+	Use:   "commit-msg <message-file>",
+	Short: "Check a commit message against the SEP conventions",
+	Long: `Check the commit message in message-file, as git's commit-msg hook does.
+
+The commit is rejected if its subject:
+  - starts with SEP-XXXX: for a SEP that does not exist
+  - starts with SEP-XXXX: for a SEP that is DONE or CANCELLED (as committed
+    in HEAD, so the commit that marks a SEP DONE passes)
+  - names a different SEP than the sep/XXXX branch it is committed to
+  - lacks a SEP-XXXX: prefix where one is required
+
+Merge and revert commits are not checked. Configure in .vibe.yaml:
+
+  hooks:
+    commit_msg: strict        # strict (reject), warn (report only) or off
+    require_prefix: sep-branch  # sep-branch, always or never
+
+Bypass once with 'git commit --no-verify'.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cfg.Hooks.CommitMsg == config.HookOff {
+			return nil
+		}
+
+		msg, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		seps, err := listSEPs()
+		if err != nil {
+			return fmt.Errorf("failed to list SEPs: %w", err)
+		}
+		byNumber := make(map[string]*sep.SEP)
+		for _, s := range seps {
+			byNumber[s.Number] = s
+		}
+
+		branchSEP := ""
+		if m := sepBranchRe.FindStringSubmatch(currentBranch()); m != nil {
+			branchSEP = m[1]
+		}
+
+		problems := sep.CheckCommit(sep.CommitSubject(string(msg)), branchSEP, cfg.Hooks.RequirePrefix,
+			func(number string) (string, bool) {
+				s, ok := byNumber[number]
+				if !ok {
+					return "", false
+				}
+				return committedStatus(s), true
+			})
+		if len(problems) == 0 {
+			return nil
+		}
+
+		verdict := "commit rejected"
+		if cfg.Hooks.CommitMsg == config.HookWarn {
+			verdict = "warning"
+		}
+		fmt.Fprintf(os.Stderr, "vibe: %s:\n", verdict)
+		for _, p := range problems {
+			fmt.Fprintf(os.Stderr, "  - %s\n", p)
+		}
+
+		if cfg.Hooks.CommitMsg == config.HookWarn {
+			return nil
+		}
+		fmt.Fprintln(os.Stderr, "Bypass with 'git commit --no-verify'; strictness is set by hooks.commit_msg in "+config.FileName)
+		return fmt.Errorf("commit message rejected")
+	},
+}
+
+// committedStatus returns the status of s as committed in HEAD, falling
+// back to the working tree for SEPs that are not committed yet
+func committedStatus(s *sep.SEP) string {
+	path, err := repoPath(s.FilePath)
+	if err != nil {
+		return s.Status
+	}
+	content, err := gitOutput("show", "HEAD:"+path)
+	if err != nil {
+		return s.Status
+	}
+	committed, err := sep.ParseContent(s.FilePath, []byte(content))
+	if err != nil {
+		return s.Status
+	}
+	return committed.Status
+}
+
+func init() {
+	RootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksCommitMsgCmd)
+	hooksInstallCmd.Flags().BoolVar(&hooksInstallForce, "force", false, "Replace an existing commit-msg hook")
+}
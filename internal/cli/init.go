@@ -17,13 +17,15 @@ var initCmd = &cobra.Command{
 	Short: "Initialize vibe workflow in a repository",
 	Long: `Initialize the vibe workflow by creating:
   - docs/seps/          SEP process documentation and template
-  - .claude/commands/   Claude Code custom commands for SEP workflow`,
+  - .claude/commands/   Claude Code custom commands for SEP workflow
+
+Both directories can be changed with sep_dir and commands_dir in .vibe.yaml.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		created := 0
 		skipped := 0
 
 		// Copy SEP templates
-		sepsCreated, sepsSkipped, err := copyEmbeddedDir("seps", "docs/seps")
+		sepsCreated, sepsSkipped, err := copyEmbeddedDir("seps", cfg.SEPDir)
 		if err != nil {
 			return err
 		}
@@ -31,7 +33,7 @@ var initCmd = &cobra.Command{
 		skipped += sepsSkipped
 
 		// Copy Claude commands
-		cmdCreated, cmdSkipped, err := copyEmbeddedDir("commands", ".claude/commands")
+		cmdCreated, cmdSkipped, err := copyEmbeddedDir("commands", cfg.CommandsDir)
 		if err != nil {
 			return err
 		}
@@ -45,7 +47,7 @@ var initCmd = &cobra.Command{
 		fmt.Println()
 
 		fmt.Println("\nNext steps:")
-		fmt.Println("  1. Review docs/seps/0000-sep-process.md")
+		fmt.Printf("  1. Review %s\n", filepath.Join(cfg.SEPDir, "0000-sep-process.md"))
 		fmt.Println("  2. Create your first SEP: vibe sep new \"Your Feature\"")
 		fmt.Println("  3. Use Claude commands: /sep-status, /sep-new, /sep-implement")
 
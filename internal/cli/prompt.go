@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// errAborted is returned when input ends in the middle of a prompt
+var errAborted = errors.New("aborted: input ended")
+
+// isTerminal reports whether f is attached to a terminal. The null device
+// is a character device too but never a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	null, err := os.Stat(os.DevNull)
+	return err != nil || !os.SameFile(info, null)
+}
+
+// prompter asks questions for interactive commands, one line per answer
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newPrompter() *prompter {
+	return &prompter{in: bufio.NewReader(os.Stdin), out: os.Stdout}
+}
+
+// line asks question and returns the trimmed answer, or def if the answer
+// is empty
+func (p *prompter) line(question, def string) (string, error) {
+	if def != "" {
+		return p.ask(fmt.Sprintf("%s [%s]: ", question, def), def)
+	}
+	return p.ask(question+": ", def)
+}
+
+// ask prints prompt as is and reads the answer, returning def if it is
+// empty
+func (p *prompter) ask(prompt, def string) (string, error) {
+	fmt.Fprint(p.out, prompt)
+
+	answer, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || answer == "") {
+		fmt.Fprintln(p.out)
+		if err == io.EOF {
+			return "", errAborted
+		}
+		return "", err
+	}
+
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// lines asks for one answer per line until an empty line or the end of
+// input. Answers that check rejects are reported and asked again.
+func (p *prompter) lines(question string, check func(string) error) ([]string, error) {
+	fmt.Fprintf(p.out, "%s (one per line, empty line to finish)\n", question)
+
+	var answers []string
+	for {
+		answer, err := p.ask("  > ", "")
+		if errors.Is(err, errAborted) {
+			return answers, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			return answers, nil
+		}
+		if check != nil {
+			if err := check(answer); err != nil {
+				fmt.Fprintf(p.out, "  %v\n", err)
+				continue
+			}
+		}
+		answers = append(answers, answer)
+	}
+}
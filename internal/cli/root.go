@@ -1,11 +1,103 @@
 package cli
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/config"
+	"github.com/valiro-ai/vibe/internal/output"
+	"github.com/valiro-ai/vibe/internal/sep"
 )
 
+// cfg is the project configuration, loaded from .vibe.yaml before any
+// command runs
+var cfg = config.Default()
+
+// outputFormat is the --output format: text for humans, json or yaml for
+// scripts
+var outputFormat string
+
+// annotationOutput marks commands that support structured --output
+const annotationOutput = "vibe/output"
+
 var RootCmd = &cobra.Command{
 	Use:   "vibe",
 	Short: "AI-native development workflow tool",
 	Long:  `Vibe is a CLI tool for managing Enhancement Proposals (EPs) in an AI-native development workflow.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutputFormat(cmd); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		if err := loadConfig(); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		return nil
+	},
+}
+
+// loadConfig loads .vibe.yaml, applies its workflow and uses its defaults
+// for flags that were not given on the command line
+func loadConfig() error {
+	if path, ok := config.Find("."); ok {
+		loaded, err := config.Load(path)
+		if err != nil {
+			return err
+		}
+		cfg = loaded
+	}
+
+	// Only restricted roles need to know who is running vibe
+	var who string
+	if sep.RolesRestricted(cfg.Roles) {
+		who = gitUserName()
+	}
+	if err := cfg.Apply(who); err != nil {
+		return err
+	}
+
+	if !sepCmd.PersistentFlags().Changed("dir") {
+		sepDir = cfg.SEPDir
+	}
+	if !feedbackCmd.PersistentFlags().Changed("file") {
+		feedbackFile = cfg.FeedbackFile
+	}
+
+	return nil
+}
+
+// checkOutputFormat validates --output and rejects structured output for
+// commands that only print text
+func checkOutputFormat(cmd *cobra.Command) error {
+	if !output.IsValidFormat(outputFormat) {
+		return fmt.Errorf("invalid output format %q (valid: %s)", outputFormat, strings.Join(output.Formats, ", "))
+	}
+	if outputFormat != output.FormatText && cmd.Annotations[annotationOutput] == "" {
+		return fmt.Errorf("'%s' does not support --output %s", cmd.CommandPath(), outputFormat)
+	}
+	return nil
+}
+
+// structuredOutput reports whether --output asks for JSON or YAML
+func structuredOutput() bool {
+	return outputFormat != output.FormatText
+}
+
+// printStructured writes doc to stdout in the --output format
+func printStructured(doc interface{}) error {
+	return output.Write(os.Stdout, outputFormat, doc)
+}
+
+// supportsOutput returns the annotations that mark a command as supporting
+// structured --output
+func supportsOutput() map[string]string {
+	return map[string]string{annotationOutput: "true"}
+}
+
+func init() {
+	RootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", output.FormatText,
+		"Output format ("+strings.Join(output.Formats, ", ")+")")
 }
@@ -1,7 +1,11 @@
 package cli
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
 )
 
 var sepDir = "docs/seps"
@@ -16,3 +20,30 @@ func init() {
 	RootCmd.AddCommand(sepCmd)
 	sepCmd.PersistentFlags().StringVarP(&sepDir, "dir", "d", "docs/seps", "Directory containing SEP files")
 }
+
+// listSEPs lists the SEPs in sepDir, warning about files that could not be
+// parsed instead of silently dropping them
+func listSEPs() ([]*sep.SEP, error) {
+	seps, parseErrs, err := sep.ListWithErrors(sepDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, parseErr := range parseErrs {
+		fmt.Fprintf(os.Stderr, "warning: skipped %v\n", parseErr)
+	}
+	if len(parseErrs) > 0 {
+		fmt.Fprintln(os.Stderr, "Run 'vibe sep lint' for details.")
+	}
+
+	return seps, nil
+}
+
+// statusHeading names a status group, marking statuses the workflow does
+// not define
+func statusHeading(status string) string {
+	if !sep.IsValidStatus(status) {
+		return status + " (unknown status)"
+	}
+	return status
+}
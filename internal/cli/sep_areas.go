@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var areasCmd = &cobra.Command{
+	Use:   "areas [number]",
+	Short: "Resolve SEP areas against the repository",
+	Long: `Expand area patterns against the files in the working tree.
+
+Files come from git: tracked files plus untracked files that are not
+ignored by .gitignore. Patterns that match no file are reported, since they
+usually mean a typo or a path that has moved.
+
+With a number, lists every file each area of that SEP covers. Without one,
+checks the areas of all active SEPs and only reports dead patterns.
+
+Exits with a non-zero status if any pattern matches nothing.
+
+Examples:
+  vibe sep areas 0003
+  vibe sep areas`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files, err := repoFiles()
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			s, err := sep.FindByNumber(sepDir, args[0])
+			if err != nil {
+				return err
+			}
+			return printAreas(s, files)
+		}
+
+		seps, err := listSEPs()
+		if err != nil {
+			return fmt.Errorf("failed to list SEPs: %w", err)
+		}
+
+		var dead int
+		for _, s := range seps {
+			if !sep.IsActive(s.Status) {
+				continue
+			}
+			for _, m := range sep.ExpandAreas(s.Areas, files) {
+				if len(m.Files) == 0 {
+					fmt.Printf("%s: area %q matches no files\n", s.ID(), m.Pattern)
+					dead++
+				}
+			}
+		}
+
+		if dead > 0 {
+			return fmt.Errorf("%d area pattern(s) match no files", dead)
+		}
+		fmt.Println("✓ Every area of the active SEPs matches at least one file")
+		return nil
+	},
+}
+
+// printAreas lists the files each area of s covers
+func printAreas(s *sep.SEP, files []string) error {
+	fmt.Printf("%s: %s\n", s.ID(), s.Title)
+
+	if len(s.Areas) == 0 {
+		fmt.Println("\n  areas: (not specified)")
+		return nil
+	}
+
+	var dead int
+	for _, m := range sep.ExpandAreas(s.Areas, files) {
+		if len(m.Files) == 0 {
+			fmt.Printf("\n  %s  ⚠️  matches no files\n", m.Pattern)
+			dead++
+			continue
+		}
+		fmt.Printf("\n  %s (%d file(s))\n", m.Pattern, len(m.Files))
+		for _, f := range m.Files {
+			fmt.Printf("    %s\n", f)
+		}
+	}
+
+	if dead > 0 {
+		fmt.Println()
+		return fmt.Errorf("%d area pattern(s) match no files", dead)
+	}
+	return nil
+}
+
+func init() {
+	sepCmd.AddCommand(areasCmd)
+}
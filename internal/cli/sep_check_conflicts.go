@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var (
+	checkConflictsStaged bool
+	checkConflictsPilot  string
+	checkConflictsSEP    string
+)
+
+var checkConflictsCmd = &cobra.Command{
+	Use:   "check-conflicts",
+	Short: "Check uncommitted changes against areas claimed by others",
+	Long: `Match the files you changed against the areas of all active, assigned
+SEPs and report each file that falls inside another pilot's claim.
+
+SEPs assigned to you are skipped. You are identified by --pilot, defaulting
+to git's user.name ("Alice" matches "@alice"). Use --sep to also skip the
+SEP you are working on.
+
+Exits with a non-zero status on any collision, so it can run as a git
+pre-commit hook:
+
+  #!/bin/sh
+  exec vibe sep check-conflicts --staged
+
+Examples:
+  vibe sep check-conflicts
+  vibe sep check-conflicts --staged --sep 0005`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files, err := changedFiles(checkConflictsStaged)
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			fmt.Println("✓ No changes to check")
+			return nil
+		}
+
+		seps, err := listSEPs()
+		if err != nil {
+			return fmt.Errorf("failed to list SEPs: %w", err)
+		}
+
+		pilot := checkConflictsPilot
+		if pilot == "" {
+			pilot = gitUserName()
+		}
+		var own string
+		if checkConflictsSEP != "" {
+			s, err := sep.FindByNumber(sepDir, checkConflictsSEP)
+			if err != nil {
+				return err
+			}
+			own = s.Number
+		}
+
+		collisions := sep.FindCollisions(seps, files, func(s *sep.SEP) bool {
+			return s.Number == own || sep.SamePilot(s.Assigned, pilot)
+		})
+
+		if len(collisions) == 0 {
+			fmt.Printf("✓ %d changed file(s), none in areas claimed by other pilots\n", len(files))
+			return nil
+		}
+
+		fmt.Println("⚠️  Changes in areas claimed by other pilots:")
+		collided := make(map[string]bool)
+		for i, c := range collisions {
+			if i == 0 || collisions[i-1].File != c.File {
+				fmt.Printf("\n  %s\n", c.File)
+			}
+			fmt.Printf("    %s: %s [%s] (area %s)\n", c.SEP.ID(), c.SEP.Title, c.SEP.Assigned, c.Area)
+			collided[c.File] = true
+		}
+		fmt.Println("\n→ Coordinate with the assigned pilots before committing")
+
+		return fmt.Errorf("%d changed file(s) collide with claimed SEPs", len(collided))
+	},
+}
+
+func init() {
+	sepCmd.AddCommand(checkConflictsCmd)
+	checkConflictsCmd.Flags().BoolVar(&checkConflictsStaged, "staged", false, "Only check staged changes (for pre-commit hooks)")
+	checkConflictsCmd.Flags().StringVar(&checkConflictsPilot, "pilot", "", "Your pilot name (default: git user.name)")
+	checkConflictsCmd.Flags().StringVar(&checkConflictsSEP, "sep", "", "SEP you are working on, skipped even if assigned to someone else")
+}
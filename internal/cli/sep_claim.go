@@ -2,96 +2,260 @@ package cli
 
 import (
 	"fmt"
-	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/valiro-ai/vibe/internal/sep"
 )
 
+// claimAttempts bounds how often a claim is rebased and pushed again after
+// the remote rejected it
+const claimAttempts = 3
+
 var claimCmd = &cobra.Command{
 	Use:   "claim <number> <pilot>",
 	Short: "Claim a SEP (assign + commit + push)",
 	Long: `Claim a SEP by assigning yourself, committing, and pushing to share with other pilots.
 
+Claiming an ACCEPTED SEP moves it to IN_PROGRESS; releasing an IN_PROGRESS
+SEP moves it back to ACCEPTED.
+
+Claims record claimed_at and a lease_until date (claims.lease_days in
+.vibe.yaml, 14 by default). Extend the lease with 'vibe sep renew'; expired
+leases show up in 'vibe sep stale' and 'vibe sep pipeline'.
+
 This is equivalent to:
+  git fetch
   vibe sep assign <number> <pilot>
-  git add <sep-file>
-  git commit -m "SEP-<number>: claimed by <pilot>"
+  git commit -m "SEP-<number>: claimed by <pilot>" <sep-file>
   git push
 
+Before assigning, the SEP file on the upstream branch is checked so a claim
+that another pilot already pushed is not overwritten. If the push is
+rejected because someone pushed first, ownership is checked again on the
+upstream: if the SEP was claimed in the meantime, the local claim commit is
+rolled back, otherwise the claim is rebased and the push retried. Other
+push failures, such as a missing upstream, keep the local commit and are
+reported. Without any remote the claim is only committed locally.
+
 Use "unclaim" or empty pilot to release:
   vibe sep claim 0001 ""`,
-	Args: cobra.ExactArgs(2),
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		number := args[0]
-		pilot := args[1]
-
 		// Find the SEP
-		foundSEP, err := sep.FindByNumber(sepDir, number)
+		foundSEP, err := sep.FindByNumber(sepDir, args[0])
 		if err != nil {
 			return err
 		}
 
-		// Check if already assigned to someone else
-		if foundSEP.Assigned != "" && foundSEP.Assigned != pilot && pilot != "" {
-			return fmt.Errorf("SEP-%s is already claimed by %s. Coordinate with them first", number, foundSEP.Assigned)
+		return claimSEP(foundSEP, args[1])
+	},
+}
+
+// claimSEP assigns pilot to foundSEP (or releases it if pilot is empty),
+// commits the SEP file and pushes, recovering from concurrent claims
+func claimSEP(foundSEP *sep.SEP, pilot string) error {
+	number := foundSEP.Number
+
+	// Get relative path for git
+	relPath, err := filepath.Rel(".", foundSEP.FilePath)
+	if err != nil {
+		relPath = foundSEP.FilePath
+	}
+
+	// Look at the latest claims before touching anything
+	upstream := gitUpstream()
+	if upstream != "" {
+		if err := runGit("fetch", "--quiet"); err != nil {
+			return fmt.Errorf("git fetch failed: %w", err)
 		}
+		if err := checkRemoteClaim(foundSEP, upstream, pilot, foundSEP.Assigned); err != nil {
+			return err
+		}
+	}
+
+	// Check if already assigned to someone else
+	if foundSEP.Assigned != "" && pilot != "" && !sep.SamePilot(foundSEP.Assigned, pilot) {
+		return fmt.Errorf("SEP-%s is already claimed by %s. Coordinate with them first", number, foundSEP.Assigned)
+	}
 
-		// Assign
-		oldAssigned := foundSEP.Assigned
+	// Assign, unless the pilot already holds the claim; the status move and
+	// lease below still apply
+	oldAssigned := foundSEP.Assigned
+	renewing := pilot != "" && sep.SamePilot(oldAssigned, pilot)
+	if !renewing {
 		if err := foundSEP.Assign(pilot); err != nil {
 			return fmt.Errorf("failed to assign: %w", err)
 		}
+	}
 
-		// Get relative path for git
-		relPath, err := filepath.Rel(".", foundSEP.FilePath)
+	// Claiming starts implementation; releasing puts the SEP back in the queue
+	if pilot != "" && foundSEP.Status == sep.StatusAccepted {
+		seps, err := sep.List(sepDir)
 		if err != nil {
-			relPath = foundSEP.FilePath
+			return fmt.Errorf("failed to list SEPs: %w", err)
 		}
-
-		// Git add
-		gitAdd := exec.Command("git", "add", relPath)
-		gitAdd.Stdout = os.Stdout
-		gitAdd.Stderr = os.Stderr
-		if err := gitAdd.Run(); err != nil {
-			return fmt.Errorf("git add failed: %w", err)
+		if err := sep.CheckTransition(foundSEP, sep.StatusInProgress, seps); err != nil {
+			fmt.Printf("⚠️  Status left at %s: %v\n", foundSEP.Status, err)
+		} else if err := foundSEP.UpdateStatus(sep.StatusInProgress); err != nil {
+			return fmt.Errorf("failed to update status: %w", err)
+		}
+	} else if pilot == "" && foundSEP.Status == sep.StatusInProgress {
+		if err := foundSEP.UpdateStatus(sep.StatusAccepted); err != nil {
+			return fmt.Errorf("failed to update status: %w", err)
 		}
+	}
 
-		// Git commit
-		var commitMsg string
-		if pilot == "" {
-			commitMsg = fmt.Sprintf("SEP-%s: unclaimed (was %s)", number, oldAssigned)
-		} else if oldAssigned == "" {
-			commitMsg = fmt.Sprintf("SEP-%s: claimed by %s", number, pilot)
-		} else {
-			commitMsg = fmt.Sprintf("SEP-%s: reassigned from %s to %s", number, oldAssigned, pilot)
+	// A new claim starts a lease; releasing drops it
+	var until string
+	if pilot == "" {
+		err = foundSEP.SetClaim("", "")
+	} else {
+		now := time.Now()
+		claimedAt := foundSEP.ClaimedAt
+		if !sep.SamePilot(oldAssigned, pilot) || claimedAt == "" {
+			claimedAt = now.Format(sep.DateFormat)
 		}
+		until = leaseUntil(now, cfg.Claims.LeaseDays)
+		err = foundSEP.SetClaim(claimedAt, until)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record claim: %w", err)
+	}
+
+	// Git commit, leaving any other staged changes alone. Claiming one's own
+	// SEP again is recorded as a renewal, which is not activity on the SEP.
+	var commitMsg string
+	if pilot == "" {
+		commitMsg = fmt.Sprintf("SEP-%s: unclaimed (was %s)", number, oldAssigned)
+	} else if renewing && until != "" {
+		commitMsg = sep.RenewalSubject(foundSEP, until)
+	} else if oldAssigned == "" || renewing {
+		commitMsg = fmt.Sprintf("SEP-%s: claimed by %s", number, pilot)
+	} else {
+		commitMsg = fmt.Sprintf("SEP-%s: reassigned from %s to %s", number, oldAssigned, pilot)
+	}
+
+	if renewing && exec.Command("git", "diff", "--quiet", "HEAD", "--", relPath).Run() == nil {
+		fmt.Printf("✓ %s is already claimed by %s\n", foundSEP.ID(), foundSEP.Assigned)
+		return nil
+	}
+
+	if err := runGit("add", relPath); err != nil {
+		return fmt.Errorf("git add failed: %w", err)
+	}
+	if err := runGit("commit", "--quiet", "-m", commitMsg, "--", relPath); err != nil {
+		return fmt.Errorf("git commit failed: %w", err)
+	}
 
-		gitCommit := exec.Command("git", "commit", "-m", commitMsg)
-		gitCommit.Stdout = os.Stdout
-		gitCommit.Stderr = os.Stderr
-		if err := gitCommit.Run(); err != nil {
-			return fmt.Errorf("git commit failed: %w", err)
+	// A local-only repository has nowhere to share the claim
+	if remotes, err := gitOutput("remote"); err == nil && remotes == "" {
+		fmt.Printf("✓ SEP-%s: %s (no remote configured; committed locally)\n", number, strings.TrimPrefix(commitMsg, "SEP-"+number+": "))
+		return nil
+	}
+
+	if err := pushClaim(foundSEP, relPath, commitMsg, upstream, pilot, oldAssigned); err != nil {
+		return err
+	}
+
+	if pilot == "" {
+		fmt.Printf("✓ SEP-%s unclaimed and pushed\n", number)
+	} else {
+		fmt.Printf("✓ SEP-%s claimed by %s and pushed\n", number, pilot)
+	}
+
+	return nil
+}
+
+// pushClaim pushes the claim commit. When the push is rejected because
+// someone pushed first, the SEP on the upstream is checked again: if
+// another pilot claimed it, the local claim commit is rolled back,
+// otherwise the claim is rebased and pushed again. Any other failure keeps
+// the local commit.
+func pushClaim(s *sep.SEP, relPath, commitMsg, upstream, pilot, previous string) error {
+	keep := func(err error) error {
+		return fmt.Errorf("%w; the claim is committed locally, run 'git push' to share it", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		rejected, err := gitPush()
+		if err == nil {
+			return nil
+		}
+		if !rejected || upstream == "" {
+			return keep(fmt.Errorf("git push failed: %w", err))
 		}
 
-		// Git push
-		gitPush := exec.Command("git", "push")
-		gitPush.Stdout = os.Stdout
-		gitPush.Stderr = os.Stderr
-		if err := gitPush.Run(); err != nil {
-			return fmt.Errorf("git push failed: %w", err)
+		if err := runGit("fetch", "--quiet"); err != nil {
+			return keep(fmt.Errorf("git fetch failed: %w", err))
+		}
+		if err := checkRemoteClaim(s, upstream, pilot, previous); err != nil {
+			return rollbackClaim(relPath, commitMsg, err)
+		}
+		if attempt == claimAttempts {
+			return keep(fmt.Errorf("git push was rejected %d times", claimAttempts))
 		}
 
-		if pilot == "" {
-			fmt.Printf("✓ SEP-%s unclaimed and pushed\n", number)
-		} else {
-			fmt.Printf("✓ SEP-%s claimed by %s and pushed\n", number, pilot)
+		fmt.Println("Remote has new commits; rebasing claim and pushing again...")
+		if err := runGit("rebase", "--quiet", "--autostash", upstream); err != nil {
+			_ = runGit("rebase", "--abort")
+			return keep(fmt.Errorf("%s changed on %s while claiming; run 'vibe sep sync'", s.ID(), upstream))
 		}
+	}
+}
+
+// leaseUntil returns the end date of a lease of days starting now, or ""
+// if leases are disabled
+func leaseUntil(now time.Time, days int) string {
+	if days <= 0 {
+		return ""
+	}
+	return now.AddDate(0, 0, days).Format(sep.DateFormat)
+}
 
+// checkRemoteClaim fails if the copy of s on upstream is assigned to
+// anyone other than pilot or previous, the local owner before the claim.
+// SEPs that do not exist on upstream yet pass.
+func checkRemoteClaim(s *sep.SEP, upstream, pilot, previous string) error {
+	path, err := repoPath(s.FilePath)
+	if err != nil {
+		return err
+	}
+	content, err := gitOutput("show", upstream+":"+path)
+	if err != nil {
 		return nil
-	},
+	}
+	remote, err := sep.ParseContent(s.FilePath, []byte(content))
+	if err != nil {
+		return nil
+	}
+
+	owner := remote.Assigned
+	if owner == "" || sep.SamePilot(owner, pilot) || sep.SamePilot(owner, previous) {
+		return nil
+	}
+	return fmt.Errorf("%s was claimed by %s on %s. Run 'vibe sep sync' and coordinate with them first",
+		s.ID(), owner, upstream)
+}
+
+// rollbackClaim undoes the local claim commit, if it is still HEAD, and
+// restores the SEP file to the committed state before it. cause is
+// returned, noting the rollback.
+func rollbackClaim(relPath, commitMsg string, cause error) error {
+	if subject, err := gitOutput("log", "-1", "--format=%s"); err != nil || subject != commitMsg {
+		return cause
+	}
+	if err := runGit("reset", "--quiet", "--soft", "HEAD~1"); err != nil {
+		return fmt.Errorf("%v (rolling back the claim commit failed: %w)", cause, err)
+	}
+	if err := runGit("checkout", "HEAD", "--", relPath); err != nil {
+		return fmt.Errorf("%v (restoring %s failed: %w)", cause, relPath, err)
+	}
+	return fmt.Errorf("%w; local claim commit rolled back", cause)
 }
 
 func init() {
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/output"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var criteriaBy string
+
+var criteriaCmd = &cobra.Command{
+	Use:   "criteria <number> [list | check N... | uncheck N... | add TEXT | remove N [TEXT]]",
+	Short: "List, check and edit a SEP's Done When criteria",
+	Long: `List, check and edit the Done When criteria of a SEP. Criteria are numbered
+from 1 in file order, as in 'vibe sep show'.
+
+Only the targeted "- [ ]" lines are rewritten. Checking a criterion records
+who checked it and when in an HTML comment on the line, which is not part
+of the criterion text:
+
+  - [x] Users can log in <!-- checked: @alice 2025-01-20 -->
+
+A "verify:" comment gives the command 'vibe sep verify' runs to prove a
+criterion; it is kept when the criterion is checked or unchecked.
+
+Checking, unchecking and adding never change the numbers of the other
+criteria. Removing one does: the criteria after it move up one number.
+Give the criterion's text after its number to make sure the right one is
+removed; if it does not match, nothing changes.
+
+Examples:
+  vibe sep criteria 0004                       # same as list
+  vibe sep criteria 0004 check 1 3
+  vibe sep criteria 0004 check 2 --by @alice
+  vibe sep criteria 0004 uncheck 2
+  vibe sep criteria 0004 add "Errors are logged"
+  vibe sep criteria 0004 remove 4              # later criteria move up
+  vibe sep criteria 0004 remove 4 "Errors are logged"`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		foundSEP, err := sep.FindByNumber(sepDir, args[0])
+		if err != nil {
+			return err
+		}
+
+		action, rest := "list", args[1:]
+		if len(rest) > 0 {
+			action, rest = rest[0], rest[1:]
+		}
+
+		switch action {
+		case "list":
+			if len(rest) > 0 {
+				return fmt.Errorf("list takes no arguments")
+			}
+			printCriteria(foundSEP)
+			return nil
+
+		case "check", "uncheck":
+			numbers, err := criterionNumbers(rest, true)
+			if err != nil {
+				return err
+			}
+			by := criteriaBy
+			if by == "" {
+				by = gitUserName()
+			}
+			today := time.Now().Format(sep.DateFormat)
+			for _, n := range numbers {
+				if action == "check" {
+					err = foundSEP.CheckCriterion(n, by, today)
+				} else {
+					err = foundSEP.UncheckCriterion(n)
+				}
+				if err != nil {
+					return fmt.Errorf("failed to %s criterion #%d: %w", action, n, err)
+				}
+				fmt.Printf("✓ %s #%d %sed: %s\n", foundSEP.ID(), n, action, foundSEP.DoneWhen[n-1])
+			}
+
+		case "add":
+			if len(rest) == 0 {
+				return fmt.Errorf("add needs the criterion text")
+			}
+			if err := foundSEP.AddCriterion(strings.Join(rest, " ")); err != nil {
+				return fmt.Errorf("failed to add criterion: %w", err)
+			}
+			fmt.Printf("✓ %s #%d added: %s\n", foundSEP.ID(), len(foundSEP.DoneWhen), foundSEP.DoneWhen[len(foundSEP.DoneWhen)-1])
+
+		case "remove":
+			if len(rest) == 0 {
+				return fmt.Errorf("missing criterion number")
+			}
+			numbers, err := criterionNumbers(rest[:1], false)
+			if err != nil {
+				return err
+			}
+			criteria := foundSEP.DoneWhen
+			if err := foundSEP.RemoveCriterion(numbers[0], strings.Join(rest[1:], " ")); err != nil {
+				return fmt.Errorf("failed to remove criterion #%d: %w", numbers[0], err)
+			}
+			fmt.Printf("✓ %s #%d removed: %s\n", foundSEP.ID(), numbers[0], criteria[numbers[0]-1])
+			if numbers[0] <= len(foundSEP.DoneWhen) {
+				fmt.Println("  Criteria after it moved up one number")
+			}
+
+		default:
+			return fmt.Errorf("unknown action %q (valid: list, check, uncheck, add, remove)", action)
+		}
+
+		fmt.Println()
+		printCriteria(foundSEP)
+		return nil
+	},
+}
+
+// criterionNumbers parses 1-based criterion numbers; many allows more than
+// one
+func criterionNumbers(args []string, many bool) ([]int, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("missing criterion number")
+	}
+	if !many && len(args) > 1 {
+		return nil, fmt.Errorf("expected one criterion number, got %d", len(args))
+	}
+
+	var numbers []int
+	for _, arg := range args {
+		n, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid criterion number %q", arg)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
+// printCriteria lists the criteria of s with their progress and who
+// checked them
+func printCriteria(s *sep.SEP) {
+	criteria := s.Criteria()
+	if len(criteria) == 0 {
+		fmt.Printf("%s has no Done When criteria. Add one with 'vibe sep criteria %s add \"...\"'\n", s.ID(), s.Number)
+		return
+	}
+
+	progress := output.Progress{Checked: s.CriteriaDone(), Total: len(s.DoneWhen)}
+	fmt.Printf("%s: %s\n", s.ID(), s.Title)
+	fmt.Printf("%s: %s %d/%d\n", sep.SectionDoneWhen, progressBar(progress), progress.Checked, progress.Total)
+	for i, c := range criteria {
+		fmt.Println(criterionLine(i+1, c))
+	}
+}
+
+// criterionLine formats criterion n, e.g.
+// "  [x] 2. Errors are logged (checked by @alice on 2025-01-20)", followed
+// by its verify command if it has one
+func criterionLine(n int, c sep.Criterion) string {
+	box := "[ ]"
+	if c.Checked {
+		box = "[x]"
+	}
+	checked := ""
+	if c.Checked && c.CheckedAt != "" {
+		if c.CheckedBy != "" {
+			checked = fmt.Sprintf(" (checked by %s on %s)", c.CheckedBy, c.CheckedAt)
+		} else {
+			checked = fmt.Sprintf(" (checked on %s)", c.CheckedAt)
+		}
+	}
+	line := fmt.Sprintf("  %s %d. %s%s", box, n, c.Text, checked)
+	if c.Verify != "" {
+		line += fmt.Sprintf("\n         verify: %s", c.Verify)
+	}
+	return line
+}
+
+func init() {
+	sepCmd.AddCommand(criteriaCmd)
+	criteriaCmd.Flags().StringVar(&criteriaBy, "by", "", "Who checked the criteria (default: git user.name)")
+}
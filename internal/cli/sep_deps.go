@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var depsCmd = &cobra.Command{
+	Use:   "deps [number]",
+	Short: "Analyze SEP dependencies",
+	Long: `Analyze the dependency graph built from depends_on.
+
+Without a number, shows the implementation order of all SEPs along with any
+dependency cycles and references to SEPs that do not exist.
+
+With a number, shows the SEP's direct dependencies, the transitive
+dependencies that are not done yet (its blockers), and the SEPs that depend
+on it.
+
+Examples:
+  vibe sep deps
+  vibe sep deps 0007`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seps, err := listSEPs()
+		if err != nil {
+			return fmt.Errorf("failed to list SEPs: %w", err)
+		}
+
+		graph := sep.NewGraph(seps)
+
+		if len(args) == 0 {
+			printGraphReport(graph)
+			return nil
+		}
+
+		foundSEP, err := sep.FindByNumber(sepDir, args[0])
+		if err != nil {
+			return err
+		}
+		s := graph.Node(foundSEP.Number)
+
+		fmt.Printf("%s: %s [%s]\n", s.ID(), s.Title, s.Status)
+
+		fmt.Println("\nDepends on:")
+		if len(s.DependsOn) == 0 {
+			fmt.Println("  (none)")
+		}
+		for _, dep := range s.DependsOn {
+			fmt.Printf("  %s\n", describeNode(graph, dep))
+		}
+
+		blockers := graph.Blockers(s.Number)
+		if len(blockers) > 0 {
+			fmt.Println("\nBlocked by (including transitive dependencies):")
+			for _, dep := range blockers {
+				fmt.Printf("  %s\n", describeNode(graph, dep))
+			}
+		}
+
+		if dependents := graph.Dependents(s.Number); len(dependents) > 0 {
+			fmt.Println("\nNeeded by:")
+			for _, dep := range dependents {
+				fmt.Printf("  %s\n", describeNode(graph, dep))
+			}
+		}
+
+		fmt.Println()
+		for _, cycle := range graph.Cycles() {
+			for _, n := range cycle {
+				if n == s.Number {
+					fmt.Printf("⚠️  Dependency cycle: %s\n", sep.FormatCycle(cycle))
+					break
+				}
+			}
+		}
+		if len(blockers) == 0 {
+			fmt.Printf("✓ All dependencies are %s\n", sep.DoneStatus)
+		} else {
+			fmt.Printf("→ %d blocking SEP(s) must be %s first\n", len(blockers), sep.DoneStatus)
+		}
+
+		return nil
+	},
+}
+
+// printGraphReport prints the implementation order and graph problems
+func printGraphReport(graph *sep.Graph) {
+	fmt.Println("SEP Dependencies")
+	fmt.Println("========================================")
+
+	order, err := graph.TopologicalOrder()
+	if err != nil {
+		fmt.Println("\nImplementation order unavailable until dependency cycles are resolved.")
+	} else {
+		fmt.Println("\nImplementation order:")
+		for i, number := range order {
+			fmt.Printf("  %2d. %s\n", i+1, describeNode(graph, number))
+		}
+	}
+
+	printGraphWarnings(graph)
+}
+
+// printGraphWarnings prints dependency cycles and missing references
+func printGraphWarnings(graph *sep.Graph) {
+	cycles := graph.Cycles()
+	missing := graph.Missing()
+	if len(cycles) == 0 && len(missing) == 0 {
+		return
+	}
+
+	fmt.Println()
+	for _, cycle := range cycles {
+		fmt.Printf("⚠️  Dependency cycle: %s\n", sep.FormatCycle(cycle))
+	}
+	for _, m := range missing {
+		fmt.Printf("⚠️  %s depends on unknown SEP-%s\n", m.SEP.ID(), m.Dependency)
+	}
+}
+
+// describeNode formats a graph node as "SEP-0001: Title [STATUS]"
+func describeNode(graph *sep.Graph, number string) string {
+	s := graph.Node(number)
+	if s == nil {
+		return fmt.Sprintf("SEP-%s (not found)", number)
+	}
+	return fmt.Sprintf("%s: %s [%s]", s.ID(), s.Title, s.Status)
+}
+
+func init() {
+	sepCmd.AddCommand(depsCmd)
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var (
+	driftUpdate bool
+	driftPrune  bool
+)
+
+var driftCmd = &cobra.Command{
+	Use:   "drift <number>",
+	Short: "Compare files changed for a SEP with its declared areas",
+	Long: `Detect area drift: files a SEP's commits changed that its areas do not
+declare, and declared areas its commits never touched.
+
+Changed files are collected from commits whose subject starts with
+"SEP-XXXX:", the convention used by 'vibe sep claim' and /sep-implement. The
+SEP file itself is ignored.
+
+With --update, undeclared files are added to the SEP's areas. With --prune,
+untouched areas are removed as well.
+
+Examples:
+  vibe sep drift 0005
+  vibe sep drift 0005 --update`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := sep.FindByNumber(sepDir, args[0])
+		if err != nil {
+			return err
+		}
+
+		changed, err := sepCommitFiles(s.Number)
+		if err != nil {
+			return err
+		}
+		if self, err := repoPath(s.FilePath); err == nil {
+			changed = removeString(changed, self)
+		}
+
+		fmt.Printf("%s: %s\n", s.ID(), s.Title)
+
+		if len(changed) == 0 {
+			fmt.Printf("\nNo files changed by commits starting with \"%s:\"\n", s.ID())
+			return nil
+		}
+
+		drift := sep.AreaDrift(s.Areas, changed)
+		fmt.Printf("\n%d file(s) changed in %s commits\n", len(changed), s.ID())
+
+		if len(drift.Undeclared) > 0 {
+			fmt.Println("\nUndeclared (changed but not covered by areas):")
+			for _, f := range drift.Undeclared {
+				fmt.Printf("  + %s\n", f)
+			}
+		}
+		if len(drift.Untouched) > 0 {
+			fmt.Println("\nUntouched (declared but not changed):")
+			for _, a := range drift.Untouched {
+				fmt.Printf("  - %s\n", a)
+			}
+		}
+
+		if len(drift.Undeclared) == 0 && len(drift.Untouched) == 0 {
+			fmt.Println("\n✓ Changes match the declared areas")
+			return nil
+		}
+
+		if !driftUpdate && !driftPrune {
+			fmt.Println("\n→ Run with --update to add undeclared files to areas (--prune also removes untouched areas)")
+			return nil
+		}
+
+		areas := append([]string(nil), s.Areas...)
+		if driftPrune {
+			for _, a := range drift.Untouched {
+				areas = removeString(areas, a)
+			}
+		}
+		areas = append(areas, drift.Undeclared...)
+
+		if err := s.SetAreas(areas); err != nil {
+			return fmt.Errorf("failed to update areas: %w", err)
+		}
+		fmt.Printf("\n✓ Updated areas of %s\n", s.ID())
+		return nil
+	},
+}
+
+// removeString returns list without any occurrence of s
+func removeString(list []string, s string) []string {
+	var kept []string
+	for _, item := range list {
+		if item != s {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+func init() {
+	sepCmd.AddCommand(driftCmd)
+	driftCmd.Flags().BoolVar(&driftUpdate, "update", false, "Add undeclared files to the SEP's areas")
+	driftCmd.Flags().BoolVar(&driftPrune, "prune", false, "Remove areas no commit touched (implies --update)")
+}
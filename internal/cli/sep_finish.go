@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var (
+	finishForce  bool
+	finishReason string
+	finishNoPush bool
+)
+
+var finishCmd = &cobra.Command{
+	Use:   "finish [number]",
+	Short: "Mark a SEP done and prepare its branch for merge",
+	Long: `Finish a SEP started with 'vibe sep start'. Without a number, the SEP is
+taken from the current sep/XXXX-<slug> branch.
+
+Finishing:
+  1. moves the SEP to the workflow's done status (DONE by default), subject
+     to its guards (use --force --reason to override, as with 'vibe sep update')
+  2. commits the SEP file as "SEP-XXXX: done"
+  3. warns if the branch is behind the branch it was started from
+  4. pushes the branch to origin (skip with --no-push)
+
+Examples:
+  vibe sep finish
+  vibe sep finish 0004 --no-push`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if finishReason != "" && !finishForce {
+			return fmt.Errorf("--reason is only recorded with --force")
+		}
+
+		branch := currentBranch()
+
+		number := ""
+		if len(args) == 1 {
+			number = args[0]
+		} else if m := sepBranchRe.FindStringSubmatch(branch); m != nil {
+			number = m[1]
+		} else {
+			return fmt.Errorf("not on a sep/XXXX branch; pass the SEP number")
+		}
+
+		foundSEP, err := sep.FindByNumber(sepDir, number)
+		if err != nil {
+			return err
+		}
+
+		// Mark done, subject to the lifecycle guards
+		done := sep.DoneStatus
+		if done == "" {
+			return fmt.Errorf("the workflow has no terminal status to finish SEPs in")
+		}
+		if foundSEP.Status != done {
+			seps, err := sep.List(sepDir)
+			if err != nil {
+				return fmt.Errorf("failed to list SEPs: %w", err)
+			}
+
+			var override *sep.Override
+			if finishForce {
+				override = &sep.Override{By: gitUserName(), Reason: finishReason}
+			}
+
+			oldStatus := foundSEP.Status
+			if err := foundSEP.Transition(done, seps, override); err != nil {
+				printOpenCriteria(foundSEP)
+				if finishForce {
+					return fmt.Errorf("failed to update SEP: %w", err)
+				}
+				return fmt.Errorf("%w\n\nUse --force to override", err)
+			}
+			fmt.Printf("Updated %s: %s → %s\n", foundSEP.ID(), oldStatus, done)
+
+			relPath, err := filepath.Rel(".", foundSEP.FilePath)
+			if err != nil {
+				relPath = foundSEP.FilePath
+			}
+			if err := runGit("add", relPath); err != nil {
+				return fmt.Errorf("git add failed: %w", err)
+			}
+			if err := runGit("commit", "--quiet", "-m", foundSEP.ID()+": done", "--", relPath); err != nil {
+				return fmt.Errorf("git commit failed: %w", err)
+			}
+		} else {
+			fmt.Printf("✓ %s is already %s\n", foundSEP.ID(), done)
+		}
+
+		if dirty, _ := gitOutput("status", "--porcelain", "--untracked-files=no"); dirty != "" {
+			fmt.Println("⚠️  Uncommitted changes are not part of the branch yet")
+		}
+
+		// Branch preparation only applies to the SEP's own branch
+		if m := sepBranchRe.FindStringSubmatch(branch); m == nil || m[1] != foundSEP.Number {
+			fmt.Printf("\n✓ %s finished on %s\n", foundSEP.ID(), branchOrHEAD(branch))
+			return nil
+		}
+
+		// Check the branch contains everything from its base
+		base := baseBranch(branch)
+		baseRef := base
+		if hasRemote("origin") {
+			if err := runGit("fetch", "--quiet", "origin"); err == nil && refExists("refs/remotes/origin/"+base) {
+				baseRef = "origin/" + base
+			}
+		}
+		behind := exec.Command("git", "merge-base", "--is-ancestor", baseRef, "HEAD").Run() != nil
+		if behind {
+			fmt.Printf("⚠️  %s is behind %s; run 'git rebase %s' before merging\n", branch, baseRef, baseRef)
+		}
+
+		if !finishNoPush && hasRemote("origin") {
+			if err := runGit("push", "--quiet", "-u", "origin", branch); err != nil {
+				return fmt.Errorf("git push failed: %w", err)
+			}
+			fmt.Printf("✓ Pushed %s to origin\n", branch)
+		}
+
+		if behind {
+			return nil
+		}
+		fmt.Printf("\n✓ %s ready to merge into %s\n", branch, base)
+		fmt.Printf("\n→ Open a pull request, or: git checkout %s && git merge --no-ff %s\n", base, branch)
+		return nil
+	},
+}
+
+// printOpenCriteria lists the Done When criteria that are not checked yet
+func printOpenCriteria(s *sep.SEP) {
+	var open []string
+	for i, criterion := range s.DoneWhen {
+		if !s.DoneWhenStatus[i] {
+			open = append(open, criterion)
+		}
+	}
+	if len(open) == 0 {
+		return
+	}
+	fmt.Println("Unchecked Done When criteria:")
+	for _, criterion := range open {
+		fmt.Printf("  - [ ] %s\n", criterion)
+	}
+	fmt.Println()
+}
+
+// branchOrHEAD names the branch, or "detached HEAD" when there is none
+func branchOrHEAD(branch string) string {
+	if branch == "" {
+		return "detached HEAD"
+	}
+	return branch
+}
+
+func init() {
+	sepCmd.AddCommand(finishCmd)
+	finishCmd.Flags().BoolVar(&finishForce, "force", false, "Mark done even if checks fail (recorded in the SEP)")
+	finishCmd.Flags().StringVar(&finishReason, "reason", "", "Reason for a forced transition (requires --force)")
+	finishCmd.Flags().BoolVar(&finishNoPush, "no-push", false, "Do not push the branch")
+}
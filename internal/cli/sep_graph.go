@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var (
+	graphFormat    string
+	graphConflicts bool
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export the SEP dependency graph as DOT or Mermaid",
+	Long: `Render the SEP dependency graph for docs, planning meetings or CI.
+
+Nodes are colored by status and labeled with the assigned pilot. Edges point
+from a dependency to the SEPs that depend on it. With --conflicts, area
+conflicts between open SEPs are drawn as dashed edges.
+
+Examples:
+  vibe sep graph | dot -Tsvg > roadmap.svg
+  vibe sep graph --format mermaid --conflicts`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seps, err := listSEPs()
+		if err != nil {
+			return fmt.Errorf("failed to list SEPs: %w", err)
+		}
+
+		graph := sep.NewGraph(seps)
+
+		var opts sep.ExportOptions
+		if graphConflicts {
+			opts.Conflicts = sep.FindConflicts(seps)
+		}
+
+		switch strings.ToLower(graphFormat) {
+		case "dot":
+			fmt.Print(graph.DOT(opts))
+		case "mermaid":
+			fmt.Print(graph.Mermaid(opts))
+		default:
+			return fmt.Errorf("unknown format: %s (use dot or mermaid)", graphFormat)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	sepCmd.AddCommand(graphCmd)
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", "dot", "Output format: dot or mermaid")
+	graphCmd.Flags().BoolVar(&graphConflicts, "conflicts", false, "Draw area conflicts as dashed edges")
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [file...]",
+	Short: "Validate SEP files",
+	Long: `Validate SEP files and report problems as file:line diagnostics.
+
+Checks required frontmatter fields, valid statuses, ISO created dates,
+depends_on references, the filename number matching the "# SEP-XXXX" heading,
+required sections and template placeholders left in.
+
+Exits with a non-zero status if any problem is found, so it can gate merges.
+
+Examples:
+  vibe sep lint                              # lint every SEP in --dir
+  vibe sep lint docs/seps/0004-login.md      # lint specific files`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var diags []sep.Diagnostic
+		var checked int
+
+		if len(args) > 0 {
+			known, err := sep.KnownNumbers(sepDir)
+			if err != nil {
+				return fmt.Errorf("failed to list SEPs: %w", err)
+			}
+			diags = sep.LintFiles(args, known)
+			checked = len(args)
+		} else {
+			var err error
+			diags, err = sep.Lint(sepDir)
+			if err != nil {
+				return fmt.Errorf("failed to lint SEPs: %w", err)
+			}
+			seps, parseErrs, _ := sep.ListWithErrors(sepDir)
+			checked = len(seps) + len(parseErrs)
+		}
+
+		for _, d := range diags {
+			fmt.Println(d)
+		}
+
+		if len(diags) > 0 {
+			files := make(map[string]bool)
+			for _, d := range diags {
+				files[d.File] = true
+			}
+			return fmt.Errorf("%d problem(s) found in %d of %d SEP file(s)", len(diags), len(files), checked)
+		}
+
+		fmt.Printf("✓ %d SEP file(s) OK\n", checked)
+		return nil
+	},
+}
+
+func init() {
+	sepCmd.AddCommand(lintCmd)
+}
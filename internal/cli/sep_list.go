@@ -3,56 +3,89 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/output"
 	"github.com/valiro-ai/vibe/internal/sep"
 )
 
-var listStatus string
+var (
+	listQuery  sep.Query
+	listFormat string
+)
 
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all SEPs",
-	Long:  `List all SEPs, optionally filtered by status.`,
+	Long: `List all SEPs, optionally filtered and sorted.
+
+Filters combine: a SEP is listed only if it matches all of them.
+
+  vibe sep list --status accepted,in_progress --unassigned
+  vibe sep list --area internal/auth --sort progress --reverse
+  vibe sep list --created-after 2025-01-01 --text oauth --conflicts
+
+SEPs are grouped by status; --sort orders them within each group, and
+orders the whole list with --output or --format.
+
+--format renders each SEP with a Go template instead, one line per SEP:
+
+  vibe sep list --format '{{.Number}}\t{{.Assigned}}\t{{.Title}}'
+  vibe sep list --format '{{.ID}} {{.Progress}} {{join .Conflicts ","}}'
+
+Templates see every SEP field (Number, Title, Status, Created, Assigned,
+DependsOn, Areas, DoneWhen, ...) plus ID, Progress ("2/5"), CriteriaDone,
+Conflicts and Stale. join, upper, lower, truncate and json are available.`,
+	Annotations:  supportsOutput(),
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		seps, err := sep.List(sepDir)
+		tmpl, err := parseFormat(listFormat)
+		if err != nil {
+			return err
+		}
+
+		all, err := listSEPs()
 		if err != nil {
 			return fmt.Errorf("failed to list SEPs: %w", err)
 		}
 
-		if len(seps) == 0 {
+		seps, err := listQuery.Run(all)
+		if err != nil {
+			return err
+		}
+
+		// Conflicts are found among all SEPs, not just the listed ones
+		var conflicts []sep.Conflict
+		if tmpl != nil {
+			conflicts = sep.FindConflicts(all)
+		}
+
+		if structuredOutput() {
+			return printStructured(output.NewSEPList(seps))
+		}
+		if tmpl != nil {
+			return printFormatted(tmpl, sepRows(seps, conflicts))
+		}
+
+		if len(all) == 0 {
 			fmt.Println("No SEPs found.")
 			return nil
 		}
-
-		// Filter by status if specified
-		if listStatus != "" {
-			var filtered []*sep.SEP
-			for _, s := range seps {
-				if s.Status == listStatus {
-					filtered = append(filtered, s)
-				}
-			}
-			seps = filtered
+		if len(seps) == 0 {
+			fmt.Println("No SEPs match.")
+			return nil
 		}
 
 		groups := sep.GroupByStatus(seps)
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
-		// Display order: ACCEPTED, DRAFT, BLOCKED, DONE, CANCELLED
-		displayOrder := []string{sep.StatusAccepted, sep.StatusDraft, sep.StatusBlocked, sep.StatusDone, sep.StatusCancelled}
+		for _, status := range sep.StatusOrder(groups) {
+			fmt.Fprintf(w, "\n%s:\n", statusHeading(status))
 
-		for _, status := range displayOrder {
-			statusSeps, ok := groups[status]
-			if !ok || len(statusSeps) == 0 {
-				continue
-			}
-
-			fmt.Fprintf(w, "\n%s:\n", status)
-
-			for _, s := range statusSeps {
+			for _, s := range groups[status] {
 				title := truncate(s.Title, 50)
 				deps := ""
 				if len(s.DependsOn) > 0 {
@@ -73,12 +106,26 @@ var listCmd = &cobra.Command{
 
 func init() {
 	sepCmd.AddCommand(listCmd)
-	listCmd.Flags().StringVarP(&listStatus, "status", "s", "", "Filter by status (DRAFT, ACCEPTED, BLOCKED, DONE, CANCELLED)")
+	addFormatFlag(listCmd, &listFormat, "SEP")
+	listCmd.Flags().StringSliceVarP(&listQuery.Statuses, "status", "s", nil, "Filter by status, comma-separated ("+strings.Join(sep.ValidStatuses, ", ")+")")
+	listCmd.Flags().StringVar(&listQuery.Assignee, "assigned", "", "Only SEPs assigned to this pilot")
+	listCmd.Flags().BoolVar(&listQuery.Unassigned, "unassigned", false, "Only SEPs without a pilot")
+	listCmd.Flags().StringVar(&listQuery.Area, "area", "", "Only SEPs with an area overlapping this path or pattern")
+	listCmd.Flags().StringVar(&listQuery.DependsOn, "depends-on", "", "Only SEPs that depend on this SEP number")
+	listCmd.Flags().StringVar(&listQuery.CreatedAfter, "created-after", "", "Only SEPs created on or after this date (YYYY-MM-DD)")
+	listCmd.Flags().StringVar(&listQuery.CreatedBefore, "created-before", "", "Only SEPs created on or before this date (YYYY-MM-DD)")
+	listCmd.Flags().StringVar(&listQuery.Text, "text", "", "Only SEPs whose title or body contains this text (case-insensitive)")
+	listCmd.Flags().BoolVar(&listQuery.HasConflicts, "conflicts", false, "Only SEPs whose areas conflict with another active SEP")
+	listCmd.Flags().StringVar(&listQuery.Sort, "sort", sep.SortNumber, "Sort by "+strings.Join(sep.SortKeys, ", "))
+	listCmd.Flags().BoolVar(&listQuery.Reverse, "reverse", false, "Reverse the sort order")
 }
 
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
+	if maxLen < 3 {
+		return s[:maxLen]
+	}
 	return s[:maxLen-3] + "..."
 }
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log <number>",
+	Short: "Show a SEP's history from git",
+	Long: `Show the timeline of a SEP, oldest first, built from git history.
+
+Commits that changed the SEP file are listed with the status changes,
+claims and Done When criteria checked off in them, and how many other
+files they changed. Commits whose subject
+starts with "SEP-XXXX:" are listed as implementation commits with the
+number of files they changed. Uncommitted changes to the SEP file come last.
+
+Example:
+  vibe sep log 0004`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := sep.FindByNumber(sepDir, args[0])
+		if err != nil {
+			return err
+		}
+		path, err := repoPath(s.FilePath)
+		if err != nil {
+			return err
+		}
+
+		fileCommits, err := gitLog("--", path)
+		if err == nil {
+			fileCommits, err = withAllFiles(fileCommits)
+		}
+		if err != nil {
+			return err
+		}
+		prefixed, err := sepCommits(s.Number)
+		if err != nil {
+			return err
+		}
+
+		// One timeline of both, oldest first
+		touched := make(map[string]bool)
+		var commits []gitCommit
+		for _, c := range fileCommits {
+			touched[c.Hash] = true
+			commits = append(commits, c)
+		}
+		for _, c := range prefixed {
+			if !touched[c.Hash] {
+				commits = append(commits, c)
+			}
+		}
+		order, err := commitOrder()
+		if err != nil {
+			return err
+		}
+		sort.SliceStable(commits, func(i, j int) bool { return order[commits[i].Hash] < order[commits[j].Hash] })
+
+		fmt.Printf("%s: %s\n", s.ID(), s.Title)
+		fmt.Println(strings.Repeat("=", 40))
+
+		if len(commits) == 0 {
+			fmt.Println("\nNo commits yet")
+		}
+
+		var prev *sep.SEP
+		for _, c := range commits {
+			fmt.Printf("\n%s  %s  %s  %s\n", c.Date, c.Hash[:7], c.Author, c.Subject)
+
+			if !touched[c.Hash] {
+				fmt.Printf("    • %d file(s) changed\n", len(c.Files))
+				continue
+			}
+
+			version := loadVersion(s, c.Hash, path)
+			if version == nil {
+				fmt.Println("    • SEP file removed or unreadable")
+				continue
+			}
+			for _, change := range sep.Diff(prev, version) {
+				fmt.Printf("    • %s\n", change)
+			}
+			if others := len(c.Files) - 1; others > 0 {
+				fmt.Printf("    • %d other file(s) changed\n", others)
+			}
+			prev = version
+		}
+
+		if prev != nil {
+			if changes := sep.Diff(prev, s); len(changes) > 0 {
+				fmt.Println("\n(uncommitted)")
+				for _, change := range changes {
+					fmt.Printf("    • %s\n", change)
+				}
+			}
+		}
+
+		return nil
+	},
+}
+
+// loadVersion parses the SEP file at path as of commit
+func loadVersion(s *sep.SEP, commit, path string) *sep.SEP {
+	content, err := gitOutput("show", commit+":"+path)
+	if err != nil {
+		return nil
+	}
+	version, err := sep.ParseContent(s.FilePath, []byte(content))
+	if err != nil {
+		return nil
+	}
+	return version
+}
+
+func init() {
+	sepCmd.AddCommand(logCmd)
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,22 +14,91 @@ import (
 	"github.com/valiro-ai/vibe/internal/templates"
 )
 
+var (
+	newWhy       string
+	newCriteria  []string
+	newAreas     []string
+	newDependsOn []string
+	newAssign    string
+	newNoInput   bool
+)
+
+// maxCompletions is how many matching paths the area prompt lists
+const maxCompletions = 20
+
 var newCmd = &cobra.Command{
 	Use:   "new [title]",
 	Short: "Create a new SEP",
-	Long:  `Create a new SEP from the template with the next available number.`,
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		title := args[0]
+	Long: `Create a new SEP from the template with the next available number.
+
+In a terminal, vibe asks for everything not given as a flag: the title,
+What & Why, Done When criteria, areas and the SEPs it depends on. End an
+area with ? to list the matching paths in the repository; a single match
+becomes the default answer. To enter a glob that ends in ?, such as
+cmd/v?, write it as cmd/v\?. Answers left empty keep the template's text.
+
+Scripts and agents pass the content as flags instead, or use --no-input to
+skip the prompts:
+
+  --why         What & Why text
+  --criterion   a Done When item (repeatable)
+  --area        a path or glob the SEP touches (repeatable)
+  --depends-on  SEP numbers this one depends on
+  --assign      pilot to assign
 
+Examples:
+  vibe sep new                                     # interactive
+  vibe sep new "API Rate Limiting" \
+    --why "Protect the API from abusive clients." \
+    --criterion "Requests over the limit get 429" \
+    --criterion "Limits are configurable per token" \
+    --area internal/ratelimit --area "cmd/api/*.go" \
+    --depends-on 0002 --assign @alice`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get next number
 		nextNum, err := sep.NextNumber(sepDir)
 		if err != nil {
 			return fmt.Errorf("failed to determine next SEP number: %w", err)
 		}
 
+		draft := sep.Draft{
+			Number:     nextNum,
+			Created:    time.Now().Format(sep.DateFormat),
+			WhatAndWhy: newWhy,
+			Criteria:   newCriteria,
+			Areas:      newAreas,
+			Assigned:   newAssign,
+		}
+		if len(args) > 0 {
+			draft.Title = args[0]
+		}
+
+		seps, err := listSEPs()
+		if err != nil {
+			return fmt.Errorf("failed to list SEPs: %w", err)
+		}
+		if draft.DependsOn, err = parseDependsOn(newDependsOn, seps); err != nil {
+			return err
+		}
+
+		if !newNoInput && isTerminal(os.Stdin) {
+			if err := promptDraft(cmd, &draft, seps); err != nil {
+				return err
+			}
+		} else if draft.Title == "" {
+			if newNoInput {
+				return fmt.Errorf("a title is required with --no-input")
+			}
+			return fmt.Errorf("a title is required (run in a terminal to be prompted for one)")
+		}
+		if err := draft.Validate(); err != nil {
+			return err
+		}
+
 		// Create slug from title
-		slug := createSlug(title)
+		slug := createSlug(draft.Title)
 		filename := fmt.Sprintf("%s-%s.md", nextNum, slug)
 		filePath := filepath.Join(sepDir, filename)
 
@@ -44,7 +114,7 @@ var newCmd = &cobra.Command{
 
 		// Try local template first, fall back to embedded
 		var templateContent []byte
-		templatePath := filepath.Join(sepDir, "SEP-TEMPLATE.md")
+		templatePath := cfg.TemplatePath(sepDir)
 		if content, err := os.ReadFile(templatePath); err == nil {
 			templateContent = content
 		} else {
@@ -54,30 +124,267 @@ var newCmd = &cobra.Command{
 				return fmt.Errorf("failed to read template: %w", err)
 			}
 			templateContent = content
+			templatePath = "built-in SEP template"
 		}
 
-		// Replace placeholders
-		today := time.Now().Format("2006-01-02")
-		content := string(templateContent)
-		content = strings.ReplaceAll(content, "SEP-XXXX", fmt.Sprintf("SEP-%s", nextNum))
-		content = strings.ReplaceAll(content, "XXXX", nextNum)
-		content = strings.ReplaceAll(content, "[Title]", title)
-		content = strings.ReplaceAll(content, "YYYY-MM-DD", today)
+		content, err := draft.Render(templateContent)
+		if err != nil {
+			return fmt.Errorf("failed to fill %s: %w", templatePath, err)
+		}
 
 		// Write new file
-		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		if err := os.WriteFile(filePath, content, 0644); err != nil {
 			return fmt.Errorf("failed to create SEP: %w", err)
 		}
 
 		fmt.Printf("Created: %s\n", filePath)
-		fmt.Printf("→ SEP-%s: %s\n", nextNum, title)
+		fmt.Printf("→ SEP-%s: %s\n", nextNum, draft.Title)
+		if missing := missingContent(draft); len(missing) > 0 {
+			fmt.Printf("Still to fill in: %s\n", strings.Join(missing, ", "))
+		}
 
 		return nil
 	},
 }
 
+// promptDraft asks for the parts of d that were not given as arguments or
+// flags
+func promptDraft(cmd *cobra.Command, d *sep.Draft, seps []*sep.SEP) error {
+	p := newPrompter()
+	var err error
+
+	for d.Title == "" {
+		if d.Title, err = p.line("Title", ""); err != nil {
+			return err
+		}
+	}
+
+	if !cmd.Flags().Changed("why") {
+		fmt.Fprintln(p.out)
+		why, err := p.lines(sep.SectionWhatAndWhy+": what are you building and why?", nil)
+		if err != nil {
+			return err
+		}
+		d.WhatAndWhy = strings.Join(why, "\n")
+	}
+
+	if !cmd.Flags().Changed("criterion") {
+		fmt.Fprintln(p.out)
+		if d.Criteria, err = p.lines(sep.SectionDoneWhen+": acceptance criteria", sep.ValidateCriterion); err != nil {
+			return err
+		}
+	}
+
+	if !cmd.Flags().Changed("area") {
+		fmt.Fprintln(p.out)
+		if d.Areas, err = promptAreas(p, pathCompleter()); err != nil {
+			return err
+		}
+	}
+
+	if !cmd.Flags().Changed("depends-on") && len(seps) > 0 {
+		fmt.Fprintln(p.out)
+		if d.DependsOn, err = promptDependsOn(p, seps); err != nil {
+			return err
+		}
+	}
+
+	if !cmd.Flags().Changed("assign") {
+		fmt.Fprintln(p.out)
+		if d.Assigned, err = p.line("Assign to (empty for unassigned)", ""); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(p.out)
+	return nil
+}
+
+// promptAreas asks for area patterns. An answer ending in "?" lists the
+// paths starting with it instead; a single match becomes the default. A
+// trailing "\?" is a literal "?", for globs.
+func promptAreas(p *prompter, complete func(prefix string) []string) ([]string, error) {
+	fmt.Fprintln(p.out, "Areas: paths or globs this SEP touches (one per line, empty line to finish;")
+	fmt.Fprintln(p.out, "end with ? to list matching paths, or \\? for a literal ?)")
+
+	var areas []string
+	def := ""
+	for {
+		prompt := "  > "
+		if def != "" {
+			prompt = fmt.Sprintf("  > [%s] ", def)
+		}
+		answer, err := p.ask(prompt, def)
+		if errors.Is(err, errAborted) {
+			return areas, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		def = ""
+
+		prefix, completing := strings.CutSuffix(answer, "?")
+		if glob, escaped := strings.CutSuffix(prefix, `\`); completing && escaped {
+			answer, completing = glob+"?", false
+		}
+		switch {
+		case answer == "":
+			return areas, nil
+
+		case completing:
+			matches := complete(prefix)
+			if len(matches) == 0 {
+				fmt.Fprintf(p.out, "  no paths start with %q\n", prefix)
+				continue
+			}
+			if len(matches) == 1 {
+				def = matches[0]
+				continue
+			}
+			for i, m := range matches {
+				if i == maxCompletions {
+					fmt.Fprintf(p.out, "    ... and %d more\n", len(matches)-maxCompletions)
+					break
+				}
+				fmt.Fprintf(p.out, "    %s\n", m)
+			}
+			if common := commonPrefix(matches); len(common) > len(prefix) {
+				def = common
+			}
+
+		default:
+			if matches := complete(answer); matches == nil {
+				fmt.Fprintf(p.out, "  warning: %q matches no path yet\n", answer)
+			}
+			areas = append(areas, answer)
+		}
+	}
+}
+
+// pathCompleter completes paths from the repository's files, or from the
+// filesystem outside a git repository
+func pathCompleter() func(prefix string) []string {
+	files, err := repoFiles()
+	if err == nil {
+		return func(prefix string) []string {
+			if strings.ContainsAny(prefix, "*?[{") {
+				if len(sep.CoveredFiles([]string{prefix}, files)) > 0 {
+					return []string{prefix}
+				}
+				return nil
+			}
+			return sep.CompletePath(prefix, files)
+		}
+	}
+
+	return func(prefix string) []string {
+		dir := "."
+		if i := strings.LastIndex(prefix, "/"); i >= 0 {
+			dir = prefix[:i+1]
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil
+		}
+
+		var paths []string
+		for _, e := range entries {
+			path := e.Name()
+			if dir != "." {
+				path = dir + path
+			}
+			if e.IsDir() {
+				path += "/"
+			}
+			paths = append(paths, path)
+		}
+		return sep.CompletePath(prefix, paths)
+	}
+}
+
+// commonPrefix returns the longest prefix shared by all of paths
+func commonPrefix(paths []string) string {
+	prefix := paths[0]
+	for _, p := range paths[1:] {
+		for !strings.HasPrefix(p, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// promptDependsOn lists the existing SEPs and asks which ones the new SEP
+// depends on
+func promptDependsOn(p *prompter, seps []*sep.SEP) ([]string, error) {
+	fmt.Fprintln(p.out, "Existing SEPs:")
+	for _, s := range seps {
+		if s.Status == sep.StatusCancelled {
+			continue
+		}
+		fmt.Fprintf(p.out, "  %s  %s [%s]\n", s.Number, s.Title, s.Status)
+	}
+
+	for {
+		answer, err := p.line("Depends on (SEP numbers, empty for none)", "")
+		if err != nil {
+			return nil, err
+		}
+		deps, err := parseDependsOn(strings.FieldsFunc(answer, func(r rune) bool {
+			return r == ',' || r == ' '
+		}), seps)
+		if err != nil {
+			fmt.Fprintf(p.out, "  %v\n", err)
+			continue
+		}
+		return deps, nil
+	}
+}
+
+// parseDependsOn normalizes SEP numbers and checks they exist
+func parseDependsOn(numbers []string, seps []*sep.SEP) ([]string, error) {
+	known := make(map[string]bool)
+	for _, s := range seps {
+		known[s.Number] = true
+	}
+
+	seen := make(map[string]bool)
+	var deps []string
+	for _, n := range numbers {
+		number := sep.NormalizeNumber(n)
+		if !known[number] {
+			return nil, fmt.Errorf("no SEP %s to depend on", n)
+		}
+		if !seen[number] {
+			seen[number] = true
+			deps = append(deps, number)
+		}
+	}
+	return deps, nil
+}
+
+// missingContent names the parts of d that still hold template text
+func missingContent(d sep.Draft) []string {
+	var missing []string
+	if strings.TrimSpace(d.WhatAndWhy) == "" {
+		missing = append(missing, sep.SectionWhatAndWhy)
+	}
+	if len(d.Criteria) == 0 {
+		missing = append(missing, sep.SectionDoneWhen)
+	}
+	if len(d.Areas) == 0 {
+		missing = append(missing, "areas")
+	}
+	return missing
+}
+
 func init() {
 	sepCmd.AddCommand(newCmd)
+	newCmd.Flags().StringVar(&newWhy, "why", "", "What & Why text")
+	newCmd.Flags().StringArrayVar(&newCriteria, "criterion", nil, "Done When criterion (repeatable)")
+	newCmd.Flags().StringArrayVar(&newAreas, "area", nil, "Path or glob the SEP touches (repeatable)")
+	newCmd.Flags().StringSliceVar(&newDependsOn, "depends-on", nil, "SEP numbers this SEP depends on")
+	newCmd.Flags().StringVar(&newAssign, "assign", "", "Pilot to assign")
+	newCmd.Flags().BoolVar(&newNoInput, "no-input", false, "Never prompt; take the content from flags only")
 }
 
 func createSlug(title string) string {
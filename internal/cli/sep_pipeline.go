@@ -5,49 +5,93 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/output"
 	"github.com/valiro-ai/vibe/internal/sep"
 )
 
+var (
+	pipelineFiles  bool
+	pipelineFormat string
+)
+
 var pipelineCmd = &cobra.Command{
 	Use:   "pipeline",
 	Short: "Show SEP pipeline with area conflicts",
-	Long:  `Display all active SEPs with their areas and highlight potential conflicts for pilot coordination.`,
+	Long: `Display all active SEPs with their areas and highlight potential conflicts for pilot coordination.
+
+By default two SEPs conflict when their area patterns could cover the same
+path. With --files, areas are expanded against the files in the repository
+and only SEPs sharing an existing file conflict; the shared files are listed.
+
+--format renders each open SEP with a Go template, as in 'vibe sep list'.`,
+	Annotations:  supportsOutput(),
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		seps, err := sep.List(sepDir)
+		tmpl, err := parseFormat(pipelineFormat)
+		if err != nil {
+			return err
+		}
+
+		seps, err := listSEPs()
 		if err != nil {
 			return fmt.Errorf("failed to list SEPs: %w", err)
 		}
 
-		if len(seps) == 0 {
+		if len(seps) == 0 && !structuredOutput() && tmpl == nil {
 			fmt.Println("No SEPs found. Run 'vibe init' to get started.")
 			return nil
 		}
 
 		// Find conflicts
-		conflicts := sep.FindConflicts(seps)
-		conflictMap := make(map[string][]string) // SEP number -> list of conflicting SEP numbers
-
-		for _, c := range conflicts {
-			conflictMap[c.SEP1.Number] = append(conflictMap[c.SEP1.Number], c.SEP2.Number)
-			conflictMap[c.SEP2.Number] = append(conflictMap[c.SEP2.Number], c.SEP1.Number)
+		var conflicts []sep.Conflict
+		if pipelineFiles {
+			files, err := repoFiles()
+			if err != nil {
+				return err
+			}
+			conflicts = sep.FindFileConflicts(seps, files)
+		} else {
+			conflicts = sep.FindConflicts(seps)
 		}
+		conflictMap := conflictNumbers(conflicts)
 
 		groups := sep.GroupByStatus(seps)
 
+		if tmpl != nil {
+			var open []*sep.SEP
+			for _, status := range sep.StatusOrder(groups) {
+				if !sep.IsTerminal(status) {
+					open = append(open, groups[status]...)
+				}
+			}
+			return printFormatted(tmpl, sepRows(open, conflicts))
+		}
+
+		if structuredOutput() {
+			doc := output.NewSEPPipeline(conflicts, len(groups[sep.DoneStatus]))
+			for _, status := range sep.StatusOrder(groups) {
+				if sep.IsTerminal(status) {
+					continue
+				}
+				for _, s := range groups[status] {
+					doc.AddSEP(s, conflictMap[s.Number], staleReasons(s, cfg.Claims.StaleDays))
+				}
+			}
+			return printStructured(doc)
+		}
+
 		fmt.Println("SEP Pipeline - Area Conflicts")
 		fmt.Println(strings.Repeat("=", 50))
 
-		// Show active SEPs (ACCEPTED, DRAFT, BLOCKED)
-		activeStatuses := []string{sep.StatusAccepted, sep.StatusDraft, sep.StatusBlocked}
-		for _, status := range activeStatuses {
-			statusSeps, ok := groups[status]
-			if !ok || len(statusSeps) == 0 {
+		// Show open SEPs (everything but terminal statuses)
+		for _, status := range sep.StatusOrder(groups) {
+			if sep.IsTerminal(status) {
 				continue
 			}
 
-			fmt.Printf("\n%s:\n", status)
+			fmt.Printf("\n%s:\n", statusHeading(status))
 
-			for _, s := range statusSeps {
+			for _, s := range groups[status] {
 				// Check for conflicts
 				conflictsWith, hasConflict := conflictMap[s.Number]
 				conflictMarker := ""
@@ -61,7 +105,13 @@ var pipelineCmd = &cobra.Command{
 					assignedMarker = fmt.Sprintf(" [%s]", s.Assigned)
 				}
 
-				fmt.Printf("  SEP-%s: %s%s%s\n", s.Number, s.Title, assignedMarker, conflictMarker)
+				staleMarker := ""
+				stale := staleReasons(s, cfg.Claims.StaleDays)
+				if len(stale) > 0 {
+					staleMarker = " ⏳ STALE"
+				}
+
+				fmt.Printf("  SEP-%s: %s%s%s%s\n", s.Number, s.Title, assignedMarker, staleMarker, conflictMarker)
 
 				// Show areas
 				if len(s.Areas) > 0 {
@@ -70,6 +120,10 @@ var pipelineCmd = &cobra.Command{
 					fmt.Printf("    areas: (not specified)\n")
 				}
 
+				if len(stale) > 0 {
+					fmt.Printf("    stale: %s\n", strings.Join(stale, ", "))
+				}
+
 				// Show dependencies
 				if len(s.DependsOn) > 0 {
 					fmt.Printf("    depends_on: SEP-%s\n", strings.Join(s.DependsOn, ", SEP-"))
@@ -77,9 +131,9 @@ var pipelineCmd = &cobra.Command{
 			}
 		}
 
-		// Show DONE count
-		if len(groups[sep.StatusDone]) > 0 {
-			fmt.Printf("\nDONE: %d SEPs completed\n", len(groups[sep.StatusDone]))
+		// Show the count of finished SEPs
+		if done := len(groups[sep.DoneStatus]); done > 0 {
+			fmt.Printf("\n%s: %d SEPs completed\n", sep.DoneStatus, done)
 		}
 
 		// Show conflict details
@@ -97,11 +151,16 @@ var pipelineCmd = &cobra.Command{
 				} else if c.SEP2.Assigned != "" {
 					assignInfo = fmt.Sprintf(" (%s assigned)", c.SEP2.Assigned)
 				}
-				fmt.Printf("  SEP-%s ↔ SEP-%s: %s%s\n",
+				inFlight := ""
+				if c.InFlight() {
+					inFlight = " - both in progress"
+				}
+				fmt.Printf("  SEP-%s ↔ SEP-%s: %s%s%s\n",
 					c.SEP1.Number,
 					c.SEP2.Number,
 					strings.Join(c.OverlapAreas, ", "),
-					assignInfo)
+					assignInfo,
+					inFlight)
 			}
 			fmt.Println("\n→ Coordinate with assigned pilots or implement sequentially")
 		}
@@ -112,4 +171,6 @@ var pipelineCmd = &cobra.Command{
 
 func init() {
 	sepCmd.AddCommand(pipelineCmd)
+	addFormatFlag(pipelineCmd, &pipelineFormat, "open SEP")
+	pipelineCmd.Flags().BoolVar(&pipelineFiles, "files", false, "Compute conflicts on the files areas cover instead of on patterns")
 }
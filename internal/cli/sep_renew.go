@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var renewDays int
+
+var renewCmd = &cobra.Command{
+	Use:   "renew <number>",
+	Short: "Extend the lease on a claimed SEP (commit + push)",
+	Long: `Extend the lease of a claimed SEP so it is not reported as stale.
+
+The new lease_until is today plus --days (default: claims.lease_days from
+.vibe.yaml). The change is committed and pushed so other pilots see it.
+
+Examples:
+  vibe sep renew 0003
+  vibe sep renew 0003 --days 30`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		foundSEP, err := sep.FindByNumber(sepDir, args[0])
+		if err != nil {
+			return err
+		}
+		if foundSEP.Assigned == "" {
+			return fmt.Errorf("%s is not claimed. Use 'vibe sep claim' first", foundSEP.ID())
+		}
+
+		days := cfg.Claims.LeaseDays
+		if cmd.Flags().Changed("days") {
+			days = renewDays
+		}
+		if days <= 0 {
+			return fmt.Errorf("leases are disabled (claims.lease_days); pass --days to renew")
+		}
+
+		until := leaseUntil(time.Now(), days)
+		if foundSEP.LeaseUntil == until {
+			fmt.Printf("✓ %s is already leased to %s until %s\n", foundSEP.ID(), foundSEP.Assigned, until)
+			return nil
+		}
+		if err := foundSEP.SetClaim(foundSEP.ClaimedAt, until); err != nil {
+			return fmt.Errorf("failed to renew lease: %w", err)
+		}
+
+		relPath, err := filepath.Rel(".", foundSEP.FilePath)
+		if err != nil {
+			relPath = foundSEP.FilePath
+		}
+		commitMsg := sep.RenewalSubject(foundSEP, until)
+		if err := runGit("add", relPath); err != nil {
+			return fmt.Errorf("git add failed: %w", err)
+		}
+		if err := runGit("commit", "--quiet", "-m", commitMsg, "--", relPath); err != nil {
+			return fmt.Errorf("git commit failed: %w", err)
+		}
+		if _, err := gitPush(); err != nil {
+			return fmt.Errorf("git push failed: %w (the renewal is committed locally; run 'git pull --rebase && git push')", err)
+		}
+
+		fmt.Printf("✓ %s leased to %s until %s and pushed\n", foundSEP.ID(), foundSEP.Assigned, until)
+		return nil
+	},
+}
+
+func init() {
+	sepCmd.AddCommand(renewCmd)
+	renewCmd.Flags().IntVar(&renewDays, "days", 0, "Lease length in days (default: claims.lease_days)")
+}
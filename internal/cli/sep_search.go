@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/output"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var searchLimit int
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search the text of all SEPs",
+	Long: `Search titles and sections of all SEPs, including DONE and CANCELLED
+ones, and list matches best first with the lines that matched.
+
+Every term must occur in a SEP; quote a phrase to search for it as a whole.
+Prefix a term with a field to search only there:
+
+  title:    the title
+  what:     What & Why          done:   Done When
+  plan:     Plan                notes:  Implementation Notes
+  status:   only SEPs in this status
+  assigned: only SEPs assigned to this pilot
+
+Examples:
+  vibe sep search rate limiting
+  vibe sep search '"rate limiting" status:DONE'
+  vibe sep search title:auth plan:jwt`,
+	Args:         cobra.MinimumNArgs(1),
+	Annotations:  supportsOutput(),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := strings.Join(args, " ")
+		parsed := sep.ParseSearchQuery(query)
+		if len(parsed.Terms) == 0 && len(parsed.Statuses) == 0 && parsed.Assignee == "" {
+			return fmt.Errorf("empty search query")
+		}
+
+		seps, err := listSEPs()
+		if err != nil {
+			return fmt.Errorf("failed to list SEPs: %w", err)
+		}
+
+		results := sep.Search(seps, parsed)
+		total := len(results)
+		if searchLimit > 0 && len(results) > searchLimit {
+			results = results[:searchLimit]
+		}
+
+		if structuredOutput() {
+			return printStructured(output.NewSEPSearch(query, results))
+		}
+
+		if total == 0 {
+			fmt.Printf("No SEPs match %q.\n", query)
+			return nil
+		}
+
+		mark := highlighter()
+		for i, r := range results {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s: %s [%s]%s\n", r.SEP.ID(), r.SEP.Title, r.SEP.Status, assignedSuffix(r.SEP))
+			for _, sn := range r.Snippets {
+				fmt.Printf("  %s: %s\n", sn.Field, mark(sn))
+			}
+		}
+
+		if total > len(results) {
+			fmt.Printf("\n---\nShowing %d of %d matches (use --limit to see more)\n", len(results), total)
+		} else {
+			fmt.Printf("\n---\nTotal: %d SEPs\n", total)
+		}
+		return nil
+	},
+}
+
+// highlighter returns a function marking the matches of a snippet: bold on
+// a terminal, **like this** otherwise
+func highlighter() func(sep.Snippet) string {
+	open, close := "**", "**"
+	if isTerminal(os.Stdout) {
+		open, close = "\x1b[1m", "\x1b[0m"
+	}
+
+	return func(sn sep.Snippet) string {
+		var b strings.Builder
+		last := 0
+		for _, h := range sn.Highlights {
+			b.WriteString(sn.Text[last:h[0]])
+			b.WriteString(open)
+			b.WriteString(sn.Text[h[0]:h[1]])
+			b.WriteString(close)
+			last = h[1]
+		}
+		b.WriteString(sn.Text[last:])
+		return b.String()
+	}
+}
+
+func init() {
+	sepCmd.AddCommand(searchCmd)
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 10, "Maximum number of SEPs to show (0 for all)")
+}
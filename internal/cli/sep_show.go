@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/output"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var showSection string
+
+// progressBarWidth is the number of cells in the Done When progress bar
+const progressBarWidth = 20
+
+var showCmd = &cobra.Command{
+	Use:   "show <number>",
+	Short: "Show a SEP in detail, or one of its sections",
+	Long: `Show a SEP: its frontmatter, What & Why, Done When progress, the status
+of its dependencies, SEPs whose areas conflict with it, how long it has been
+claimed and whether a plan has been written. The file's sections are listed
+with their line ranges.
+
+--section prints the raw content of a single section instead.
+
+Examples:
+  vibe sep show 0004                     # detailed view
+  vibe sep show 0004 --section Plan      # print the Plan section
+  vibe sep show 0004 --output json       # machine-readable view`,
+	Args:         cobra.ExactArgs(1),
+	Annotations:  supportsOutput(),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if showSection != "" && structuredOutput() {
+			return fmt.Errorf("--section cannot be combined with --output %s", outputFormat)
+		}
+
+		foundSEP, err := sep.FindByNumber(sepDir, args[0])
+		if err != nil {
+			return err
+		}
+
+		if showSection != "" {
+			section := foundSEP.Section(showSection)
+			if section == nil {
+				return fmt.Errorf("%s has no section %q", foundSEP.ID(), showSection)
+			}
+			fmt.Println(section.Body)
+			return nil
+		}
+
+		seps, err := listSEPs()
+		if err != nil {
+			return fmt.Errorf("failed to list SEPs: %w", err)
+		}
+		doc := showDocument(foundSEP, seps)
+
+		if structuredOutput() {
+			return printStructured(doc)
+		}
+
+		printSEP(foundSEP, doc)
+		return nil
+	},
+}
+
+// showDocument gathers everything 'sep show' reports about s. seps should
+// include s, whose conflicts and dependencies are looked up among them.
+func showDocument(s *sep.SEP, seps []*sep.SEP) output.SEPShow {
+	var conflicts []sep.Conflict
+	for _, c := range sep.FindConflicts(seps) {
+		if c.SEP1.Number == s.Number || c.SEP2.Number == s.Number {
+			conflicts = append(conflicts, c)
+		}
+	}
+
+	var ageDays *int
+	if age, ok := s.ClaimAge(time.Now()); ok && s.Assigned != "" {
+		ageDays = &age
+	}
+
+	return output.NewSEPShow(s, sep.NewGraph(seps), conflicts, ageDays, staleReasons(s, cfg.Claims.StaleDays))
+}
+
+// printSEP prints the detailed text view of s
+func printSEP(s *sep.SEP, doc output.SEPShow) {
+	fmt.Printf("%s: %s\n", s.ID(), s.Title)
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Status:   %s\n", s.Status)
+	fmt.Printf("Created:  %s\n", s.Created)
+	fmt.Printf("Assigned: %s\n", describeClaim(doc.Claim))
+	if len(s.Areas) > 0 {
+		fmt.Printf("Areas:    %s\n", strings.Join(s.Areas, ", "))
+	} else {
+		fmt.Printf("Areas:    (not specified)\n")
+	}
+	fmt.Printf("File:     %s\n", s.FilePath)
+	if len(doc.Claim.Stale) > 0 {
+		fmt.Printf("⏳ Stale: %s\n", strings.Join(doc.Claim.Stale, ", "))
+	}
+	for _, forced := range s.ForcedTransitions {
+		fmt.Printf("⚠️  Forced: %s\n", forced)
+	}
+
+	fmt.Printf("\n%s:\n", sep.SectionWhatAndWhy)
+	if s.WhatAndWhy != "" {
+		for _, line := range strings.Split(s.WhatAndWhy, "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+	} else {
+		fmt.Println("  (not written yet)")
+	}
+
+	fmt.Printf("\n%s: %s %d/%d\n", sep.SectionDoneWhen, progressBar(doc.Progress), doc.Progress.Checked, doc.Progress.Total)
+	for i, c := range s.Criteria() {
+		fmt.Println(criterionLine(i+1, c))
+	}
+
+	if len(doc.Dependencies) > 0 {
+		fmt.Println("\nDepends on:")
+		for _, dep := range doc.Dependencies {
+			switch {
+			case !dep.Found:
+				fmt.Printf("  ? SEP-%s (not found)\n", dep.Number)
+			case dep.Done:
+				fmt.Printf("  ✓ SEP-%s: %s [%s]\n", dep.Number, dep.Title, dep.Status)
+			default:
+				fmt.Printf("  ✗ SEP-%s: %s [%s]\n", dep.Number, dep.Title, dep.Status)
+			}
+		}
+	}
+
+	if len(doc.Conflicts) > 0 {
+		fmt.Println("\nConflicts:")
+		for _, c := range doc.Conflicts {
+			other := c.SEPs[0]
+			if other == s.Number {
+				other = c.SEPs[1]
+			}
+			inFlight := ""
+			if c.InFlight {
+				inFlight = " - both in progress"
+			}
+			fmt.Printf("  ⚠️  SEP-%s: %s%s\n", other, strings.Join(c.Overlap, ", "), inFlight)
+		}
+	}
+
+	if doc.HasPlan {
+		fmt.Println("\nPlan: written")
+	} else {
+		fmt.Printf("\nPlan: not written yet (run /sep-plan %s)\n", s.Number)
+	}
+
+	fmt.Println("\nSections:")
+	for _, section := range s.Sections {
+		if section.EndLine < section.StartLine {
+			fmt.Printf("  %s (empty, line %d)\n", section.Heading, section.Line)
+			continue
+		}
+		fmt.Printf("  %s (lines %d-%d)\n", section.Heading, section.StartLine, section.EndLine)
+	}
+}
+
+// describeClaim formats the pilot and claim dates, e.g.
+// "@alice (claimed 2025-01-10, 3 days ago; lease until 2025-01-24)"
+func describeClaim(c output.Claim) string {
+	if c.Assigned == "" {
+		return "(unassigned)"
+	}
+
+	var details []string
+	if c.ClaimedAt != "" {
+		claimed := "claimed " + c.ClaimedAt
+		if c.AgeDays != nil {
+			switch *c.AgeDays {
+			case 0:
+				claimed += ", today"
+			case 1:
+				claimed += ", 1 day ago"
+			default:
+				claimed += fmt.Sprintf(", %d days ago", *c.AgeDays)
+			}
+		}
+		details = append(details, claimed)
+	}
+	if c.LeaseUntil != "" {
+		details = append(details, "lease until "+c.LeaseUntil)
+	}
+	if len(details) == 0 {
+		return c.Assigned
+	}
+	return fmt.Sprintf("%s (%s)", c.Assigned, strings.Join(details, "; "))
+}
+
+// progressBar draws checked criteria as filled cells, e.g. "[████░░░░]"
+func progressBar(p output.Progress) string {
+	filled := 0
+	if p.Total > 0 {
+		filled = p.Checked * progressBarWidth / p.Total
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled) + "]"
+}
+
+func init() {
+	sepCmd.AddCommand(showCmd)
+	showCmd.Flags().StringVar(&showSection, "section", "", "Print only the named section (e.g., Plan)")
+}
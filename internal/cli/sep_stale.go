@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var staleDays int
+
+var staleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "List claims whose lease expired or that went quiet",
+	Long: `List active, assigned SEPs whose claim looks abandoned: the lease_until
+date has passed, or there have been no "SEP-XXXX:" commits for --days
+(default: claims.stale_days from .vibe.yaml). Commits on any local or
+remote-tracking branch count, so work on a SEP branch or in a worktree
+keeps the claim fresh. Without such commits, claimed_at is used as the last
+activity.
+
+Pilots can extend their lease with 'vibe sep renew' or by claiming the SEP
+again; renewal commits do not count as activity, so an idle claim still
+shows up here. Editors can release a stale claim with
+'vibe sep claim <number> ""'.
+
+Examples:
+  vibe sep stale
+  vibe sep stale --days 7`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seps, err := listSEPs()
+		if err != nil {
+			return fmt.Errorf("failed to list SEPs: %w", err)
+		}
+
+		days := cfg.Claims.StaleDays
+		if cmd.Flags().Changed("days") {
+			days = staleDays
+		}
+
+		var found int
+		for _, s := range seps {
+			reasons := staleReasons(s, days)
+			if len(reasons) == 0 {
+				continue
+			}
+			if found == 0 {
+				fmt.Println("Stale claims:")
+			}
+			found++
+			fmt.Printf("\n  %s: %s [%s] (%s)\n", s.ID(), s.Title, s.Assigned, s.Status)
+			fmt.Printf("    %s\n", strings.Join(reasons, ", "))
+		}
+
+		if found == 0 {
+			fmt.Println("✓ No stale claims")
+			return nil
+		}
+		fmt.Println("\n→ Renew with 'vibe sep renew <number>' or release with 'vibe sep claim <number> \"\"'")
+		return nil
+	},
+}
+
+// staleReasons explains why the claim on s is stale, looking up its last
+// SEP commit in git; idleDays <= 0 only checks the lease
+func staleReasons(s *sep.SEP, idleDays int) []string {
+	if s.Assigned == "" || !sep.IsActive(s.Status) {
+		return nil
+	}
+	var last time.Time
+	if idleDays > 0 {
+		// Outside a git repository only claimed_at is known
+		last, _ = lastSEPCommit(s.Number)
+	}
+	return sep.StaleReasons(s, last, time.Now(), idleDays)
+}
+
+func init() {
+	sepCmd.AddCommand(staleCmd)
+	staleCmd.Flags().IntVar(&staleDays, "days", 0, "Days without SEP commits before a claim is stale (default: claims.stale_days)")
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var (
+	startWorktree bool
+	startPath     string
+)
+
+// sepBranchRe extracts the SEP number from a branch made by 'sep start'
+var sepBranchRe = regexp.MustCompile(`^sep/(\d{4})(-|$)`)
+
+var startCmd = &cobra.Command{
+	Use:   "start <number> <pilot>",
+	Short: "Claim a SEP and create its branch",
+	Long: `Start implementing a SEP: claim it for the pilot (see 'vibe sep claim')
+and switch to a sep/XXXX-<slug> branch for the work.
+
+With --worktree, the branch is checked out in a separate git worktree
+instead (default: a sibling directory named <repo>-sep-XXXX), so several
+pilots or agents can work on different SEPs in parallel from one clone.
+
+If the branch already exists it is reused. When you are done, run
+'vibe sep finish' on the branch.
+
+Examples:
+  vibe sep start 0004 @alice
+  vibe sep start 0004 @alice --worktree
+  vibe sep start 0004 @alice --worktree --path ../agent-2`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pilot := args[1]
+		if pilot == "" {
+			return fmt.Errorf("a pilot is required to start a SEP")
+		}
+
+		foundSEP, err := sep.FindByNumber(sepDir, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Claim first so other pilots see the SEP is taken. A pilot who
+		// already holds it still gets the status move and a fresh lease.
+		if err := claimSEP(foundSEP, pilot); err != nil {
+			return err
+		}
+
+		base := currentBranch()
+		branch := sepBranch(foundSEP)
+		exists := refExists("refs/heads/" + branch)
+
+		if startWorktree || startPath != "" {
+			path := startPath
+			if path == "" {
+				root, err := repoRoot()
+				if err != nil {
+					return err
+				}
+				path = filepath.Join(filepath.Dir(root), fmt.Sprintf("%s-sep-%s", filepath.Base(root), foundSEP.Number))
+			}
+
+			gitArgs := []string{"worktree", "add", "--quiet", path, branch}
+			if !exists {
+				gitArgs = []string{"worktree", "add", "--quiet", "-b", branch, path}
+			}
+			if err := runGit(gitArgs...); err != nil {
+				return fmt.Errorf("git worktree add failed: %w", err)
+			}
+			recordBaseBranch(branch, base, exists)
+
+			fmt.Printf("✓ Worktree for %s on branch %s at %s\n", foundSEP.ID(), branch, path)
+			fmt.Printf("\n→ cd %s and run /sep-plan %s\n", path, foundSEP.Number)
+			return nil
+		}
+
+		gitArgs := []string{"checkout", "--quiet", branch}
+		if !exists {
+			gitArgs = []string{"checkout", "--quiet", "-b", branch}
+		}
+		if err := runGit(gitArgs...); err != nil {
+			return fmt.Errorf("git checkout failed: %w", err)
+		}
+		recordBaseBranch(branch, base, exists)
+
+		fmt.Printf("✓ Switched to branch %s\n", branch)
+		fmt.Printf("\n→ Run /sep-plan %s\n", foundSEP.Number)
+		return nil
+	},
+}
+
+// sepBranch returns the branch name for a SEP, e.g. "sep/0004-user-login"
+func sepBranch(s *sep.SEP) string {
+	if slug := createSlug(s.Title); slug != "" {
+		return fmt.Sprintf("sep/%s-%s", s.Number, slug)
+	}
+	return "sep/" + s.Number
+}
+
+// recordBaseBranch remembers which branch a new SEP branch was created
+// from, so 'sep finish' knows what it will be merged into
+func recordBaseBranch(branch, base string, existed bool) {
+	if existed || base == "" {
+		return
+	}
+	_ = runGit("config", "branch."+branch+".vibeBase", base)
+}
+
+// baseBranch returns the branch a SEP branch will be merged into: the one
+// recorded by 'sep start', else the remote's default branch, else "main"
+func baseBranch(branch string) string {
+	if base, err := gitOutput("config", "branch."+branch+".vibeBase"); err == nil && base != "" {
+		return base
+	}
+	if head, err := gitOutput("symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		return strings.TrimPrefix(head, "origin/")
+	}
+	return "main"
+}
+
+func init() {
+	sepCmd.AddCommand(startCmd)
+	startCmd.Flags().BoolVar(&startWorktree, "worktree", false, "Check the branch out in a separate git worktree")
+	startCmd.Flags().StringVar(&startPath, "path", "", "Worktree directory (implies --worktree)")
+}
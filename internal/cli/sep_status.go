@@ -5,19 +5,28 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/output"
 	"github.com/valiro-ai/vibe/internal/sep"
 )
 
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show SEP status and recommend next action",
-	Long:  `Display current state of all SEPs and recommend what to work on next.`,
+	Long: `Display current state of all SEPs and recommend what to work on next.
+
+Status groups, their descriptions and the next-action recommendations follow
+the workflow in .vibe.yaml (see 'vibe config').`,
+	Annotations: supportsOutput(),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		seps, err := sep.List(sepDir)
+		seps, err := listSEPs()
 		if err != nil {
 			return fmt.Errorf("failed to list SEPs: %w", err)
 		}
 
+		if structuredOutput() {
+			return printStructured(statusDocument(seps))
+		}
+
 		if len(seps) == 0 {
 			fmt.Println("No SEPs found. Run 'vibe init' to get started.")
 			return nil
@@ -28,103 +37,58 @@ var statusCmd = &cobra.Command{
 		fmt.Println("SEP Status")
 		fmt.Println(strings.Repeat("=", 40))
 
-		// Show ACCEPTED SEPs (ready for implementation)
-		if len(groups[sep.StatusAccepted]) > 0 {
-			fmt.Println("\nACCEPTED (ready for implementation):")
-			for _, s := range groups[sep.StatusAccepted] {
-				deps := ""
-				if len(s.DependsOn) > 0 {
-					deps = fmt.Sprintf(" [depends on: SEP-%s]", strings.Join(s.DependsOn, ", SEP-"))
-				}
-				fmt.Printf("  - SEP-%s: %s (created %s)%s\n", s.Number, s.Title, s.Created, deps)
+		for _, status := range sep.StatusOrder(groups) {
+			def, _ := sep.LookupStatus(status)
+			if def.Description != "" {
+				fmt.Printf("\n%s (%s):\n", status, def.Description)
+			} else {
+				fmt.Printf("\n%s:\n", statusHeading(status))
 			}
-		}
 
-		// Show DRAFT SEPs (awaiting editor review)
-		if len(groups[sep.StatusDraft]) > 0 {
-			fmt.Println("\nDRAFT (awaiting review):")
-			for _, s := range groups[sep.StatusDraft] {
+			for _, s := range groups[status] {
+				// Finished SEPs only need their title
+				if sep.IsTerminal(status) {
+					fmt.Printf("  - SEP-%s: %s\n", s.Number, s.Title)
+					continue
+				}
+
 				deps := ""
 				if len(s.DependsOn) > 0 {
 					deps = fmt.Sprintf(" [depends on: SEP-%s]", strings.Join(s.DependsOn, ", SEP-"))
 				}
-				fmt.Printf("  - SEP-%s: %s (created %s)%s\n", s.Number, s.Title, s.Created, deps)
-			}
-		}
-
-		// Show BLOCKED SEPs
-		if len(groups[sep.StatusBlocked]) > 0 {
-			fmt.Println("\nBLOCKED:")
-			for _, s := range groups[sep.StatusBlocked] {
-				fmt.Printf("  - SEP-%s: %s (created %s)\n", s.Number, s.Title, s.Created)
+				fmt.Printf("  - SEP-%s: %s (created %s)%s%s\n", s.Number, s.Title, s.Created, assignedSuffix(s), deps)
 			}
 		}
 
-		// Show DONE SEPs
-		if len(groups[sep.StatusDone]) > 0 {
-			fmt.Println("\nDONE:")
-			for _, s := range groups[sep.StatusDone] {
-				fmt.Printf("  - SEP-%s: %s\n", s.Number, s.Title)
-			}
-		}
-
-		// Show CANCELLED SEPs
-		if len(groups[sep.StatusCancelled]) > 0 {
-			fmt.Println("\nCANCELLED:")
-			for _, s := range groups[sep.StatusCancelled] {
-				fmt.Printf("  - SEP-%s: %s\n", s.Number, s.Title)
-			}
-		}
+		printGraphWarnings(sep.NewGraph(seps))
 
 		// Recommend next action
 		fmt.Println()
-
-		// Priority 1: ACCEPTED SEPs ready for implementation
-		if len(groups[sep.StatusAccepted]) > 0 {
-			for _, s := range groups[sep.StatusAccepted] {
-				canImplement := true
-				for _, dep := range s.DependsOn {
-					depDone := false
-					for _, done := range groups[sep.StatusDone] {
-						if done.Number == dep {
-							depDone = true
-							break
-						}
-					}
-					if !depDone {
-						canImplement = false
-						break
-					}
-				}
-				if canImplement {
-					if len(s.DependsOn) == 0 {
-						fmt.Printf("NEXT: Implement SEP-%s with /sep-plan then /sep-implement\n", s.Number)
-					} else {
-						fmt.Printf("NEXT: Implement SEP-%s (dependencies met)\n", s.Number)
-					}
-					return nil
-				}
-			}
-		}
-
-		// Priority 2: DRAFT SEPs need editor review
-		if len(groups[sep.StatusDraft]) > 0 {
-			fmt.Printf("NEXT: Review SEP-%s (editor approval needed before implementation)\n", groups[sep.StatusDraft][0].Number)
-			return nil
-		}
-
-		// Priority 3: Blocked SEPs
-		if len(groups[sep.StatusBlocked]) > 0 {
-			fmt.Println("NEXT: Resolve blocked SEPs to continue")
-			return nil
-		}
-
-		fmt.Println("NEXT: Create a new SEP with 'vibe sep new \"Feature Name\"'")
+		fmt.Printf("NEXT: %s\n", sep.Recommend(seps))
 
 		return nil
 	},
 }
 
+// statusDocument builds the structured form of 'sep status'
+func statusDocument(seps []*sep.SEP) output.SEPStatus {
+	doc := output.NewSEPStatus(sep.NewGraph(seps), sep.NextAction(seps))
+	groups := sep.GroupByStatus(seps)
+	for _, status := range sep.StatusOrder(groups) {
+		def, _ := sep.LookupStatus(status)
+		doc.AddGroup(status, def.Description, groups[status])
+	}
+	return doc
+}
+
 func init() {
 	sepCmd.AddCommand(statusCmd)
 }
+
+// assignedSuffix formats the assigned pilot as " [@alice]", if any
+func assignedSuffix(s *sep.SEP) string {
+	if s.Assigned == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [%s]", s.Assigned)
+}
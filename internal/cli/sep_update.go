@@ -2,32 +2,39 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"slices"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/valiro-ai/vibe/internal/sep"
 )
 
+var (
+	updateForce  bool
+	updateReason string
+)
+
 var updateCmd = &cobra.Command{
 	Use:   "update [number] [status]",
 	Short: "Update a SEP's status",
-	Long: fmt.Sprintf(`Update the status of a SEP.
-
-Valid statuses: %s`, strings.Join(sep.ValidStatuses, ", ")),
-	Args: cobra.ExactArgs(2),
+	// Long is generated from the workflow in effect when help is shown
+	Long:         updateLong(),
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		number := args[0]
 		newStatus := strings.ToUpper(args[1])
 
-		// Validate status
-		validStatus := false
-		for _, s := range sep.ValidStatuses {
-			if s == newStatus {
-				validStatus = true
-				break
-			}
+		// Only forced transitions are recorded, so a reason alone would be lost
+		if updateReason != "" && !updateForce {
+			return fmt.Errorf("--reason is only recorded with --force")
 		}
-		if !validStatus {
+
+		// Validate status
+		if !sep.IsValidStatus(newStatus) {
 			return fmt.Errorf("invalid status: %s\nValid statuses: %s", newStatus, strings.Join(sep.ValidStatuses, ", "))
 		}
 
@@ -37,19 +44,111 @@ Valid statuses: %s`, strings.Join(sep.ValidStatuses, ", ")),
 			return err
 		}
 
+		seps, err := sep.List(sepDir)
+		if err != nil {
+			return fmt.Errorf("failed to list SEPs: %w", err)
+		}
+
 		oldStatus := foundSEP.Status
 
-		// Update status using proper YAML parsing
-		if err := foundSEP.UpdateStatus(newStatus); err != nil {
-			return fmt.Errorf("failed to update SEP: %w", err)
+		var override *sep.Override
+		if updateForce {
+			override = &sep.Override{By: gitUserName(), Reason: updateReason}
+		}
+
+		if err := foundSEP.Transition(newStatus, seps, override); err != nil {
+			if updateForce {
+				return fmt.Errorf("failed to update SEP: %w", err)
+			}
+			return fmt.Errorf("%w\n\nUse --force to override", err)
 		}
 
 		fmt.Printf("Updated SEP-%s: %s → %s\n", foundSEP.Number, oldStatus, newStatus)
+		if updateForce {
+			fmt.Println("⚠️  Forced: transition checks skipped and recorded in forced_transitions")
+		}
 
 		return nil
 	},
 }
 
+// updateLong describes the statuses, transitions, guards and restricted
+// roles of the current workflow
+func updateLong() string {
+	var b strings.Builder
+	b.WriteString("Update the status of a SEP.\n\nStatuses and the moves they allow:\n")
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	var guarded []sep.StatusDef
+	for _, def := range sep.CurrentWorkflow().Statuses {
+		to := strings.Join(def.Transitions, ", ")
+		if len(def.Transitions) == 0 {
+			to = "(final)"
+		}
+		fmt.Fprintf(w, "  %s\t→ %s\n", def.Name, to)
+		if len(def.Guards) > 0 {
+			guarded = append(guarded, def)
+		}
+	}
+	w.Flush()
+
+	if len(guarded) > 0 {
+		b.WriteString("\nGuards are checked before a SEP enters these statuses:\n")
+		var names []string
+		for _, def := range guarded {
+			fmt.Fprintf(w, "  %s\t%s\n", def.Name, strings.Join(def.Guards, ", "))
+			for _, name := range def.Guards {
+				if !slices.Contains(names, name) {
+					names = append(names, name)
+				}
+			}
+		}
+		w.Flush()
+		b.WriteString("\n")
+		for _, name := range names {
+			fmt.Fprintf(w, "  %s\t%s\n", name, sep.GuardDescription(name))
+		}
+		w.Flush()
+	}
+
+	if sep.RolesRestricted(sep.Roles) {
+		b.WriteString("\nRoles limit who may move SEPs into their statuses (git user.name):\n")
+		for _, r := range sep.Roles {
+			if len(r.Members) > 0 {
+				fmt.Fprintf(w, "  %s\t%s\t%s\n", r.Name, strings.Join(r.Statuses, ", "), strings.Join(r.Members, ", "))
+			}
+		}
+		w.Flush()
+	}
+
+	b.WriteString(`
+The workflow comes from .vibe.yaml (see 'vibe config'). Use --force to bypass
+these checks; the override is recorded in the SEP's forced_transitions
+frontmatter field.
+
+Examples:
+  vibe sep update 0001 ACCEPTED
+  vibe sep update 0001 DONE --force --reason "criteria tracked in issue #12"`)
+	return b.String()
+}
+
+// gitUserName returns the configured git user name, falling back to $USER
+func gitUserName() string {
+	out, err := exec.Command("git", "config", "user.name").Output()
+	if name := strings.TrimSpace(string(out)); err == nil && name != "" {
+		return name
+	}
+	return os.Getenv("USER")
+}
+
 func init() {
 	sepCmd.AddCommand(updateCmd)
+	// Show the workflow of .vibe.yaml rather than the built-in one
+	updateCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		if err := loadConfig(); err == nil {
+			cmd.Long = updateLong()
+		}
+		cmd.Parent().HelpFunc()(cmd, args)
+	})
+	updateCmd.Flags().BoolVar(&updateForce, "force", false, "Bypass transition checks (recorded in the SEP)")
+	updateCmd.Flags().StringVar(&updateReason, "reason", "", "Reason for a forced transition (requires --force)")
 }
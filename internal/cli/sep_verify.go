@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+var (
+	verifyTimeout time.Duration
+	verifyNoCheck bool
+	verifyBy      string
+)
+
+// verifyOutputLines is how much of a failing command's output is shown
+const verifyOutputLines = 10
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <number> [criterion...]",
+	Short: "Run the verify commands of a SEP's Done When criteria",
+	Long: `Run the verify command of each Done When criterion that has one and report
+whether it passed. A criterion gets a command from a "verify:" comment:
+
+  - [ ] Login is rate-limited <!-- verify: go test ./auth -run TestRateLimit -->
+
+Commands run through sh from the project root (the directory holding
+.vibe.yaml), each limited by verify.timeout in the config. Criteria whose
+command passes are checked; failing ones are left as they are. The command
+exits non-zero if any verification fails.
+
+Set verify.require_for_done, or add the criteria-verified guard to the
+workflow's done status (workflow.done), to require passing verifications
+before a SEP can be finished.
+
+Examples:
+  vibe sep verify 0004                 # run all verify commands
+  vibe sep verify 0004 2 3             # only criteria #2 and #3
+  vibe sep verify 0004 --no-check      # report without checking criteria
+  vibe sep verify 0004 --timeout 30s`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		foundSEP, err := sep.FindByNumber(sepDir, args[0])
+		if err != nil {
+			return err
+		}
+
+		var numbers []int
+		if len(args) > 1 {
+			if numbers, err = criterionNumbers(args[1:], true); err != nil {
+				return err
+			}
+		}
+
+		verifier := cfg.Verifier()
+		if cmd.Flags().Changed("timeout") {
+			if verifyTimeout <= 0 {
+				return fmt.Errorf("--timeout must be positive")
+			}
+			verifier.Timeout = verifyTimeout
+		}
+
+		fmt.Printf("%s: %s\n", foundSEP.ID(), foundSEP.Title)
+		results, err := verifier.Verify(foundSEP, numbers)
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			fmt.Println("No criteria with a verify command. Add one as <!-- verify: COMMAND --> on a Done When line.")
+			return nil
+		}
+
+		by := verifyBy
+		if by == "" {
+			by = gitUserName()
+		}
+		today := time.Now().Format(sep.DateFormat)
+
+		failed := 0
+		for _, r := range results {
+			elapsed := r.Duration.Round(100 * time.Millisecond)
+			if !r.Passed {
+				failed++
+				fmt.Printf("  ✗ %d. %s (%v, %s)\n", r.Number, r.Criterion.Text, r.Err, elapsed)
+				fmt.Printf("       $ %s\n", r.Criterion.Verify)
+				for _, line := range lastLines(r.Output, verifyOutputLines) {
+					fmt.Printf("       %s\n", line)
+				}
+				continue
+			}
+
+			fmt.Printf("  ✓ %d. %s (%s)\n", r.Number, r.Criterion.Text, elapsed)
+			if r.Criterion.Checked || verifyNoCheck {
+				continue
+			}
+			if err := foundSEP.CheckCriterion(r.Number, by, today); err != nil {
+				return fmt.Errorf("failed to check criterion #%d: %w", r.Number, err)
+			}
+			fmt.Printf("       checked\n")
+		}
+
+		fmt.Printf("\n---\n%d passed, %d failed\n", len(results)-failed, failed)
+		if failed > 0 {
+			return fmt.Errorf("%d of %d verifications failed", failed, len(results))
+		}
+		return nil
+	},
+}
+
+// lastLines returns the last n lines of text
+func lastLines(text string, n int) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) > n {
+		lines = append([]string{fmt.Sprintf("... (%d lines omitted)", len(lines)-n)}, lines[len(lines)-n:]...)
+	}
+	return lines
+}
+
+func init() {
+	sepCmd.AddCommand(verifyCmd)
+	verifyCmd.Flags().DurationVar(&verifyTimeout, "timeout", 0, "Limit per command (default: verify.timeout from the config)")
+	verifyCmd.Flags().BoolVar(&verifyNoCheck, "no-check", false, "Report results without checking passing criteria")
+	verifyCmd.Flags().StringVar(&verifyBy, "by", "", "Who checks passing criteria (default: git user.name)")
+}
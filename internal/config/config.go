@@ -0,0 +1,220 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/valiro-ai/vibe/internal/sep"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the project config file, looked up from the working
+// directory towards the filesystem root
+const FileName = ".vibe.yaml"
+
+// Config is the project-level vibe configuration
+type Config struct {
+	SEPDir       string       `yaml:"sep_dir"`       // Directory containing SEP files
+	FeedbackFile string       `yaml:"feedback_file"` // Feedback log written by 'vibe feedback'
+	CommandsDir  string       `yaml:"commands_dir"`  // Where 'vibe init' installs Claude commands
+	Templates    Templates    `yaml:"templates"`
+	Roles        []sep.Role   `yaml:"roles,omitempty"`
+	Workflow     sep.Workflow `yaml:"workflow"`
+	Claims       Claims       `yaml:"claims"`
+	Hooks        Hooks        `yaml:"hooks"`
+	Verify       Verify       `yaml:"verify"`
+
+	// Path is the file the config was loaded from, empty for defaults
+	Path string `yaml:"-"`
+}
+
+// Templates holds paths to project-specific templates
+type Templates struct {
+	SEP string `yaml:"sep,omitempty"` // Template for 'vibe sep new' (default: <sep_dir>/SEP-TEMPLATE.md)
+}
+
+// Claims controls claim leases and stale-claim detection
+type Claims struct {
+	LeaseDays int `yaml:"lease_days"` // Lease written by 'vibe sep claim' and 'renew'; 0 disables leases
+	StaleDays int `yaml:"stale_days"` // Days without SEP commits before a claim is stale; 0 disables
+}
+
+// Hook strictness levels
+const (
+	HookStrict = "strict" // reject offending commits
+	HookWarn   = "warn"   // report problems but let the commit through
+	HookOff    = "off"
+)
+
+// Hooks controls the git hooks installed by 'vibe hooks install'
+type Hooks struct {
+	CommitMsg     string `yaml:"commit_msg"`     // strict, warn or off
+	RequirePrefix string `yaml:"require_prefix"` // sep-branch, always or never
+}
+
+// Verify controls the verify commands run by 'vibe sep verify' and the
+// criteria-verified guard
+type Verify struct {
+	Timeout        time.Duration `yaml:"timeout"`          // Limit per command, e.g. 30s or 5m
+	RequireForDone bool          `yaml:"require_for_done"` // Add the criteria-verified guard to the done status
+}
+
+// Default returns the built-in configuration
+func Default() *Config {
+	return &Config{
+		SEPDir:       "docs/seps",
+		FeedbackFile: "docs/feedback.log",
+		CommandsDir:  ".claude/commands",
+		Roles:        sep.DefaultRoles(),
+		Workflow:     sep.DefaultWorkflow(),
+		Claims:       Claims{LeaseDays: 14, StaleDays: 14},
+		Hooks:        Hooks{CommitMsg: HookStrict, RequirePrefix: sep.RequirePrefixSEPBranch},
+		Verify:       Verify{Timeout: sep.DefaultVerifyTimeout},
+	}
+}
+
+// Find looks for FileName in dir and its parents, returning its path
+func Find(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		path := filepath.Join(dir, FileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Load reads the config at path on top of the defaults. Keys missing from
+// the file keep their default values; a workflow that lists its own
+// statuses replaces the built-in ones.
+func Load(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+
+	// A custom status list brings its own display and recommendation order,
+	// and the built-in roles name statuses it may not have
+	var custom struct {
+		Workflow struct {
+			Statuses []sep.StatusDef `yaml:"statuses"`
+		} `yaml:"workflow"`
+	}
+	if err := yaml.Unmarshal(content, &custom); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(custom.Workflow.Statuses) > 0 {
+		cfg.Workflow = sep.Workflow{}
+		cfg.Roles = nil
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := cfg.Hooks.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if cfg.Verify.Timeout <= 0 {
+		return nil, fmt.Errorf("%s: verify.timeout must be positive", path)
+	}
+
+	if cfg.Verify.RequireForDone && !cfg.Workflow.RequireGuard(cfg.Workflow.FinalStatus(), "criteria-verified") {
+		return nil, fmt.Errorf("%s: verify.require_for_done: workflow has no done status", path)
+	}
+
+	if cfg.Workflow.IdleNext == "" {
+		cfg.Workflow.IdleNext = sep.DefaultWorkflow().IdleNext
+	}
+
+	// Paths are relative to the directory holding the config file
+	base := filepath.Dir(path)
+	for _, p := range []*string{&cfg.SEPDir, &cfg.FeedbackFile, &cfg.CommandsDir, &cfg.Templates.SEP} {
+		*p = resolvePath(base, *p)
+	}
+
+	cfg.Path = path
+	return cfg, nil
+}
+
+// validate checks the hook settings hold known values
+func (h Hooks) validate() error {
+	switch h.CommitMsg {
+	case HookStrict, HookWarn, HookOff:
+	default:
+		return fmt.Errorf("hooks.commit_msg: unknown value %q (use %s, %s or %s)", h.CommitMsg, HookStrict, HookWarn, HookOff)
+	}
+	switch h.RequirePrefix {
+	case sep.RequirePrefixSEPBranch, sep.RequirePrefixAlways, sep.RequirePrefixNever:
+	default:
+		return fmt.Errorf("hooks.require_prefix: unknown value %q (use %s, %s or %s)", h.RequirePrefix,
+			sep.RequirePrefixSEPBranch, sep.RequirePrefixAlways, sep.RequirePrefixNever)
+	}
+	return nil
+}
+
+// resolvePath makes a config-relative path usable from the working directory
+func resolvePath(base, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	abs := filepath.Join(base, path)
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, abs); err == nil {
+			return rel
+		}
+	}
+	return abs
+}
+
+// Apply makes the config's workflow, roles and verify settings the ones
+// used by the sep package. who is the user moving SEPs, checked against
+// the members of restricted roles.
+func (c *Config) Apply(who string) error {
+	sep.SetVerifier(c.Verifier())
+	err := sep.SetWorkflow(c.Workflow)
+	if err == nil {
+		err = sep.SetRoles(c.Roles, who)
+	}
+	if err != nil && c.Path != "" {
+		return fmt.Errorf("%s: %w", c.Path, err)
+	}
+	return err
+}
+
+// Verifier runs verify commands from the project root: the directory
+// holding the config file, or the working directory without one
+func (c *Config) Verifier() sep.Verifier {
+	v := sep.Verifier{Timeout: c.Verify.Timeout}
+	if c.Path != "" {
+		v.Dir = filepath.Dir(c.Path)
+	}
+	return v
+}
+
+// TemplatePath returns the SEP template path for 'vibe sep new'
+func (c *Config) TemplatePath(sepDir string) string {
+	if c.Templates.SEP != "" {
+		return c.Templates.SEP
+	}
+	return filepath.Join(sepDir, "SEP-TEMPLATE.md")
+}
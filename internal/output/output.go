@@ -0,0 +1,76 @@
+// Package output renders command results as versioned JSON or YAML
+// documents for scripts, instead of the human-readable text the commands
+// print by default
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --output
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Formats lists the valid output formats
+var Formats = []string{FormatText, FormatJSON, FormatYAML}
+
+// SchemaVersion is the version of every document this package writes. It
+// is bumped when a field is removed, renamed or changes meaning; fields may
+// be added without a new version.
+const SchemaVersion = 1
+
+// Document kinds
+const (
+	KindSEPList      = "sep-list"
+	KindSEPStatus    = "sep-status"
+	KindSEPPipeline  = "sep-pipeline"
+	KindFeedbackList = "feedback-list"
+	KindSEPSearch    = "sep-search"
+	KindSEPShow      = "sep-show"
+)
+
+// Header starts every document so consumers can check what they got
+type Header struct {
+	SchemaVersion int    `json:"schema_version" yaml:"schema_version"`
+	Kind          string `json:"kind" yaml:"kind"`
+}
+
+func newHeader(kind string) Header {
+	return Header{SchemaVersion: SchemaVersion, Kind: kind}
+}
+
+// IsValidFormat reports whether format is one of Formats
+func IsValidFormat(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// Write encodes doc to w as JSON or YAML
+func Write(w io.Writer, format string, doc interface{}) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
@@ -0,0 +1,339 @@
+package output
+
+import (
+	"github.com/valiro-ai/vibe/internal/sep"
+)
+
+// Lists are always written, empty rather than null, so consumers do not
+// have to tell a missing field from an empty one.
+
+// SEP is the stable representation of a SEP
+type SEP struct {
+	Number     string      `json:"number" yaml:"number"`
+	ID         string      `json:"id" yaml:"id"`
+	Title      string      `json:"title" yaml:"title"`
+	Status     string      `json:"status" yaml:"status"`
+	Created    string      `json:"created" yaml:"created"`
+	Assigned   string      `json:"assigned" yaml:"assigned"`
+	ClaimedAt  string      `json:"claimed_at" yaml:"claimed_at"`
+	LeaseUntil string      `json:"lease_until" yaml:"lease_until"`
+	DependsOn  []string    `json:"depends_on" yaml:"depends_on"`
+	Areas      []string    `json:"areas" yaml:"areas"`
+	Criteria   []Criterion `json:"criteria" yaml:"criteria"`
+	File       string      `json:"file" yaml:"file"`
+}
+
+// Criterion is one Done When item
+type Criterion struct {
+	Text    string `json:"text" yaml:"text"`
+	Checked bool   `json:"checked" yaml:"checked"`
+	Verify  string `json:"verify" yaml:"verify"` // command proving the criterion, empty if none
+}
+
+// Conflict is an overlap between the areas of two SEPs
+type Conflict struct {
+	SEPs     []string `json:"seps" yaml:"seps"`           // the two SEP numbers
+	Overlap  []string `json:"overlap" yaml:"overlap"`     // overlapping areas, or shared files
+	InFlight bool     `json:"in_flight" yaml:"in_flight"` // both SEPs are being implemented or reviewed
+}
+
+// Recommendation is the suggested next action
+type Recommendation struct {
+	SEP    string `json:"sep" yaml:"sep"` // number of the SEP it is about, "" if none
+	Action string `json:"action" yaml:"action"`
+}
+
+// FeedbackEntry is one entry of the feedback log
+type FeedbackEntry struct {
+	Time    string `json:"time" yaml:"time"` // "YYYY-MM-DD HH:MM", local time
+	SEP     string `json:"sep" yaml:"sep"`   // linked SEP number, "" if none
+	Message string `json:"message" yaml:"message"`
+}
+
+// MissingDependency is a depends_on entry naming no known SEP
+type MissingDependency struct {
+	SEP        string `json:"sep" yaml:"sep"`
+	Dependency string `json:"dependency" yaml:"dependency"`
+}
+
+// SEPList is the document written by 'vibe sep list'
+type SEPList struct {
+	Header `yaml:",inline"`
+	SEPs   []SEP `json:"seps" yaml:"seps"`
+}
+
+// StatusGroup is the SEPs in one status
+type StatusGroup struct {
+	Status      string `json:"status" yaml:"status"`
+	Description string `json:"description" yaml:"description"`
+	SEPs        []SEP  `json:"seps" yaml:"seps"`
+}
+
+// SEPStatus is the document written by 'vibe sep status'
+type SEPStatus struct {
+	Header              `yaml:",inline"`
+	Groups              []StatusGroup       `json:"groups" yaml:"groups"`
+	Cycles              [][]string          `json:"cycles" yaml:"cycles"`
+	MissingDependencies []MissingDependency `json:"missing_dependencies" yaml:"missing_dependencies"`
+	Recommendation      Recommendation      `json:"recommendation" yaml:"recommendation"`
+}
+
+// PipelineSEP is an open SEP in the pipeline
+type PipelineSEP struct {
+	SEP           `yaml:",inline"`
+	ConflictsWith []string `json:"conflicts_with" yaml:"conflicts_with"` // SEP numbers
+	Stale         []string `json:"stale" yaml:"stale"`                   // why the claim looks abandoned
+}
+
+// SEPPipeline is the document written by 'vibe sep pipeline'
+type SEPPipeline struct {
+	Header    `yaml:",inline"`
+	SEPs      []PipelineSEP `json:"seps" yaml:"seps"`
+	Done      int           `json:"done" yaml:"done"` // number of done SEPs, which are not listed
+	Conflicts []Conflict    `json:"conflicts" yaml:"conflicts"`
+}
+
+// FeedbackList is the document written by 'vibe feedback list'
+type FeedbackList struct {
+	Header  `yaml:",inline"`
+	Entries []FeedbackEntry `json:"entries" yaml:"entries"`
+}
+
+// SearchResult is a SEP matching a search query
+type SearchResult struct {
+	SEP      SEP       `json:"sep" yaml:"sep"`
+	Score    int       `json:"score" yaml:"score"`
+	Snippets []Snippet `json:"snippets" yaml:"snippets"`
+}
+
+// Snippet is a line of a SEP containing a match
+type Snippet struct {
+	Field      string   `json:"field" yaml:"field"`           // "Title" or a section heading
+	Text       string   `json:"text" yaml:"text"`             // the line, shortened around the match
+	Highlights [][2]int `json:"highlights" yaml:"highlights"` // [start, end) byte offsets of matches in text
+}
+
+// SEPSearch is the document written by 'vibe sep search'
+type SEPSearch struct {
+	Header  `yaml:",inline"`
+	Query   string         `json:"query" yaml:"query"`
+	Results []SearchResult `json:"results" yaml:"results"`
+}
+
+// Dependency is a depends_on entry with the state of the SEP it names
+type Dependency struct {
+	Number string `json:"number" yaml:"number"`
+	Title  string `json:"title" yaml:"title"`   // "" if not found
+	Status string `json:"status" yaml:"status"` // "" if not found
+	Found  bool   `json:"found" yaml:"found"`
+	Done   bool   `json:"done" yaml:"done"`
+}
+
+// Claim describes who holds a SEP and for how long
+type Claim struct {
+	Assigned   string   `json:"assigned" yaml:"assigned"`
+	ClaimedAt  string   `json:"claimed_at" yaml:"claimed_at"`
+	LeaseUntil string   `json:"lease_until" yaml:"lease_until"`
+	AgeDays    *int     `json:"age_days" yaml:"age_days"` // days since claimed_at, null if unknown
+	Stale      []string `json:"stale" yaml:"stale"`       // why the claim looks abandoned
+}
+
+// Progress counts checked Done When criteria
+type Progress struct {
+	Checked int `json:"checked" yaml:"checked"`
+	Total   int `json:"total" yaml:"total"`
+}
+
+// SectionInfo locates a "## " section in the SEP file
+type SectionInfo struct {
+	Heading   string `json:"heading" yaml:"heading"`
+	Line      int    `json:"line" yaml:"line"`             // line of the heading
+	StartLine int    `json:"start_line" yaml:"start_line"` // first line of the body
+	EndLine   int    `json:"end_line" yaml:"end_line"`     // last line of the body, start_line-1 if empty
+}
+
+// SEPShow is the document written by 'vibe sep show'
+type SEPShow struct {
+	Header       `yaml:",inline"`
+	SEP          SEP           `json:"sep" yaml:"sep"`
+	WhatAndWhy   string        `json:"what_and_why" yaml:"what_and_why"`
+	Progress     Progress      `json:"progress" yaml:"progress"`
+	Dependencies []Dependency  `json:"dependencies" yaml:"dependencies"`
+	Conflicts    []Conflict    `json:"conflicts" yaml:"conflicts"`
+	Claim        Claim         `json:"claim" yaml:"claim"`
+	HasPlan      bool          `json:"has_plan" yaml:"has_plan"`
+	Sections     []SectionInfo `json:"sections" yaml:"sections"`
+}
+
+// NewSEP converts a parsed SEP
+func NewSEP(s *sep.SEP) SEP {
+	out := SEP{
+		Number:     s.Number,
+		ID:         s.ID(),
+		Title:      s.Title,
+		Status:     s.Status,
+		Created:    s.Created,
+		Assigned:   s.Assigned,
+		ClaimedAt:  s.ClaimedAt,
+		LeaseUntil: s.LeaseUntil,
+		DependsOn:  list(s.DependsOn),
+		Areas:      list(s.Areas),
+		Criteria:   []Criterion{},
+		File:       s.FilePath,
+	}
+	parsed := s.Criteria()
+	for i, text := range s.DoneWhen {
+		c := Criterion{Text: text, Checked: s.DoneWhenStatus[i]}
+		if len(parsed) == len(s.DoneWhen) {
+			c.Verify = parsed[i].Verify
+		}
+		out.Criteria = append(out.Criteria, c)
+	}
+	return out
+}
+
+// NewSEPs converts a list of parsed SEPs
+func NewSEPs(seps []*sep.SEP) []SEP {
+	out := []SEP{}
+	for _, s := range seps {
+		out = append(out, NewSEP(s))
+	}
+	return out
+}
+
+// NewConflict converts a conflict
+func NewConflict(c sep.Conflict) Conflict {
+	return Conflict{
+		SEPs:     []string{c.SEP1.Number, c.SEP2.Number},
+		Overlap:  list(c.OverlapAreas),
+		InFlight: c.InFlight(),
+	}
+}
+
+// NewRecommendation converts a recommendation
+func NewRecommendation(r sep.Recommendation) Recommendation {
+	out := Recommendation{Action: r.Action}
+	if r.SEP != nil {
+		out.SEP = r.SEP.Number
+	}
+	return out
+}
+
+// NewSEPList builds a 'sep list' document
+func NewSEPList(seps []*sep.SEP) SEPList {
+	return SEPList{Header: newHeader(KindSEPList), SEPs: NewSEPs(seps)}
+}
+
+// NewSEPStatus builds a 'sep status' document without groups; add them
+// with AddGroup
+func NewSEPStatus(graph *sep.Graph, next sep.Recommendation) SEPStatus {
+	doc := SEPStatus{
+		Header:              newHeader(KindSEPStatus),
+		Groups:              []StatusGroup{},
+		Cycles:              [][]string{},
+		MissingDependencies: []MissingDependency{},
+		Recommendation:      NewRecommendation(next),
+	}
+	doc.Cycles = append(doc.Cycles, graph.Cycles()...)
+	for _, m := range graph.Missing() {
+		doc.MissingDependencies = append(doc.MissingDependencies,
+			MissingDependency{SEP: m.SEP.Number, Dependency: m.Dependency})
+	}
+	return doc
+}
+
+// AddGroup appends the SEPs of one status
+func (d *SEPStatus) AddGroup(status, description string, seps []*sep.SEP) {
+	d.Groups = append(d.Groups, StatusGroup{Status: status, Description: description, SEPs: NewSEPs(seps)})
+}
+
+// NewSEPPipeline builds a 'sep pipeline' document without SEPs; add them
+// with AddSEP
+func NewSEPPipeline(conflicts []sep.Conflict, done int) SEPPipeline {
+	doc := SEPPipeline{
+		Header:    newHeader(KindSEPPipeline),
+		SEPs:      []PipelineSEP{},
+		Done:      done,
+		Conflicts: []Conflict{},
+	}
+	for _, c := range conflicts {
+		doc.Conflicts = append(doc.Conflicts, NewConflict(c))
+	}
+	return doc
+}
+
+// AddSEP appends an open SEP with the SEPs it conflicts with and its stale
+// reasons
+func (d *SEPPipeline) AddSEP(s *sep.SEP, conflictsWith, stale []string) {
+	d.SEPs = append(d.SEPs, PipelineSEP{SEP: NewSEP(s), ConflictsWith: list(conflictsWith), Stale: list(stale)})
+}
+
+// NewFeedbackList builds a 'feedback list' document
+func NewFeedbackList(entries []FeedbackEntry) FeedbackList {
+	return FeedbackList{Header: newHeader(KindFeedbackList), Entries: append([]FeedbackEntry{}, entries...)}
+}
+
+// NewSEPSearch builds a 'sep search' document
+func NewSEPSearch(query string, results []sep.SearchResult) SEPSearch {
+	doc := SEPSearch{Header: newHeader(KindSEPSearch), Query: query, Results: []SearchResult{}}
+	for _, r := range results {
+		result := SearchResult{SEP: NewSEP(r.SEP), Score: r.Score, Snippets: []Snippet{}}
+		for _, sn := range r.Snippets {
+			highlights := append([][2]int{}, sn.Highlights...)
+			result.Snippets = append(result.Snippets, Snippet{Field: sn.Field, Text: sn.Text, Highlights: highlights})
+		}
+		doc.Results = append(doc.Results, result)
+	}
+	return doc
+}
+
+// NewSEPShow builds a 'sep show' document for s. graph resolves its
+// dependencies, conflicts are those involving s, ageDays is nil if the
+// claim date is unknown and stale lists why the claim looks abandoned.
+func NewSEPShow(s *sep.SEP, graph *sep.Graph, conflicts []sep.Conflict, ageDays *int, stale []string) SEPShow {
+	doc := SEPShow{
+		Header:       newHeader(KindSEPShow),
+		SEP:          NewSEP(s),
+		WhatAndWhy:   s.WhatAndWhy,
+		Progress:     Progress{Checked: s.CriteriaDone(), Total: len(s.DoneWhen)},
+		Dependencies: []Dependency{},
+		Conflicts:    []Conflict{},
+		Claim: Claim{
+			Assigned:   s.Assigned,
+			ClaimedAt:  s.ClaimedAt,
+			LeaseUntil: s.LeaseUntil,
+			AgeDays:    ageDays,
+			Stale:      list(stale),
+		},
+		HasPlan:  s.HasPlan(),
+		Sections: []SectionInfo{},
+	}
+	for _, number := range s.DependsOn {
+		dep := Dependency{Number: number}
+		if node := graph.Node(number); node != nil {
+			dep.Title, dep.Status, dep.Found = node.Title, node.Status, true
+			dep.Done = sep.IsDone(node.Status)
+		}
+		doc.Dependencies = append(doc.Dependencies, dep)
+	}
+	for _, c := range conflicts {
+		doc.Conflicts = append(doc.Conflicts, NewConflict(c))
+	}
+	for _, section := range s.Sections {
+		doc.Sections = append(doc.Sections, SectionInfo{
+			Heading:   section.Heading,
+			Line:      section.Line,
+			StartLine: section.StartLine,
+			EndLine:   section.EndLine,
+		})
+	}
+	return doc
+}
+
+// list returns s, or an empty list instead of nil
+func list(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
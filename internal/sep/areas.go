@@ -0,0 +1,133 @@
+package sep
+
+import (
+	"sort"
+	"strings"
+)
+
+// AreaMatch is an area pattern together with the files it covers
+type AreaMatch struct {
+	Pattern string
+	Files   []string
+}
+
+// ExpandAreas resolves each area pattern against a list of repository
+// files (relative to the repository root), preserving pattern order.
+// Patterns that cover no file get an empty Files list.
+func ExpandAreas(areas, files []string) []AreaMatch {
+	matches := make([]AreaMatch, 0, len(areas))
+	for _, pattern := range areas {
+		m := AreaMatch{Pattern: pattern}
+		for _, f := range files {
+			if MatchArea(pattern, f) {
+				m.Files = append(m.Files, f)
+			}
+		}
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+// CoveredFiles returns the files covered by any of the area patterns, in
+// the order of files
+func CoveredFiles(areas, files []string) []string {
+	var covered []string
+	for _, f := range files {
+		for _, pattern := range areas {
+			if MatchArea(pattern, f) {
+				covered = append(covered, f)
+				break
+			}
+		}
+	}
+	return covered
+}
+
+// FindFileConflicts is like FindConflicts, but compares the concrete files
+// each SEP's areas cover rather than the patterns themselves, so only
+// overlaps on files that exist are reported. OverlapAreas lists the shared
+// files.
+func FindFileConflicts(seps []*SEP, files []string) []Conflict {
+	covered := make(map[*SEP][]string)
+	for _, s := range seps {
+		covered[s] = CoveredFiles(s.Areas, files)
+	}
+
+	return findConflicts(seps, func(a, b *SEP) []string {
+		inB := make(map[string]bool, len(covered[b]))
+		for _, f := range covered[b] {
+			inB[f] = true
+		}
+		var shared []string
+		for _, f := range covered[a] {
+			if inB[f] {
+				shared = append(shared, f)
+			}
+		}
+		return shared
+	})
+}
+
+// Drift compares the files a SEP actually changed with its declared areas
+type Drift struct {
+	Undeclared []string // changed files no area covers
+	Untouched  []string // areas that cover none of the changed files
+}
+
+// AreaDrift computes the drift between declared areas and changed files
+func AreaDrift(areas, changed []string) Drift {
+	var d Drift
+	covered := make(map[string]bool)
+	for _, f := range CoveredFiles(areas, changed) {
+		covered[f] = true
+	}
+	for _, f := range changed {
+		if !covered[f] {
+			d.Undeclared = append(d.Undeclared, f)
+		}
+	}
+	for _, m := range ExpandAreas(areas, changed) {
+		if len(m.Files) == 0 {
+			d.Untouched = append(d.Untouched, m.Pattern)
+		}
+	}
+	return d
+}
+
+// SetAreas replaces the areas field in the SEP file
+func (s *SEP) SetAreas(areas []string) error {
+	if areas == nil {
+		areas = []string{}
+	}
+	err := s.EditFrontmatter(func(fm *FrontmatterEditor) error {
+		return fm.Set("areas", areas)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.Areas = areas
+	return nil
+}
+
+// CompletePath returns the entries of files, and the directories holding
+// them, that extend prefix by one path component. Directories end in "/".
+func CompletePath(prefix string, files []string) []string {
+	seen := make(map[string]bool)
+	var completions []string
+	for _, f := range files {
+		if !strings.HasPrefix(f, prefix) {
+			continue
+		}
+		c := f
+		if i := strings.Index(f[len(prefix):], "/"); i >= 0 {
+			c = f[:len(prefix)+i+1]
+		}
+		if !seen[c] {
+			seen[c] = true
+			completions = append(completions, c)
+		}
+	}
+	sort.Strings(completions)
+	return completions
+}
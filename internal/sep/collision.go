@@ -0,0 +1,40 @@
+package sep
+
+import "strings"
+
+// Collision is a changed file that falls inside an area of an assigned SEP
+type Collision struct {
+	File string
+	SEP  *SEP
+	Area string // first area of the SEP covering File
+}
+
+// FindCollisions matches files against the areas of all active, assigned
+// SEPs for which skip returns false. A file covered by several SEPs yields
+// one collision per SEP; collisions are ordered by file, then SEP.
+func FindCollisions(seps []*SEP, files []string, skip func(*SEP) bool) []Collision {
+	var collisions []Collision
+	for _, f := range files {
+		for _, s := range seps {
+			if s.Assigned == "" || !IsActive(s.Status) || (skip != nil && skip(s)) {
+				continue
+			}
+			for _, area := range s.Areas {
+				if MatchArea(area, f) {
+					collisions = append(collisions, Collision{File: f, SEP: s, Area: area})
+					break
+				}
+			}
+		}
+	}
+	return collisions
+}
+
+// SamePilot reports whether two pilot names refer to the same person,
+// ignoring case and a leading "@" ("@alice" and "Alice" match)
+func SamePilot(a, b string) bool {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), "@"))
+	}
+	return normalize(a) != "" && normalize(a) == normalize(b)
+}
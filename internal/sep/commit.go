@@ -0,0 +1,77 @@
+package sep
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Values of the require_prefix commit rule
+const (
+	RequirePrefixAlways    = "always"     // every commit needs a SEP-XXXX: prefix
+	RequirePrefixSEPBranch = "sep-branch" // only commits on sep/XXXX branches
+	RequirePrefixNever     = "never"
+)
+
+// commitPrefixRe matches the SEP reference at the start of a commit subject
+var commitPrefixRe = regexp.MustCompile(`^SEP-(\d+):`)
+
+// autosquashRe matches the markers git adds for fixup and squash commits
+var autosquashRe = regexp.MustCompile(`^((fixup|squash|amend)! )+`)
+
+// CommitSubject returns the first line of a commit message that is neither
+// blank nor a "#" comment
+func CommitSubject(msg string) string {
+	for _, line := range strings.Split(msg, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return line
+		}
+	}
+	return ""
+}
+
+// CommitSEP returns the zero-padded SEP number a subject starts with, as in
+// "SEP-0004: Add login form"
+func CommitSEP(subject string) (string, bool) {
+	m := commitPrefixRe.FindStringSubmatch(autosquashRe.ReplaceAllString(subject, ""))
+	if m == nil {
+		return "", false
+	}
+	return fmt.Sprintf("%04s", m[1]), true
+}
+
+// CheckCommit validates a commit subject against the SEP prefix
+// convention. branchSEP is the SEP number of the sep/XXXX branch being
+// committed to, if any. status looks up the status of a SEP, reporting
+// false for SEPs that do not exist. Merge and revert commits are exempt.
+func CheckCommit(subject, branchSEP, requirePrefix string, status func(number string) (string, bool)) []string {
+	if subject == "" || strings.HasPrefix(subject, "Merge ") || strings.HasPrefix(subject, "Revert ") {
+		return nil
+	}
+
+	number, ok := CommitSEP(subject)
+	if !ok {
+		switch {
+		case requirePrefix == RequirePrefixAlways:
+			return []string{"commit subject must start with SEP-XXXX:"}
+		case requirePrefix == RequirePrefixSEPBranch && branchSEP != "":
+			return []string{fmt.Sprintf("commits on this branch must start with SEP-%s:", branchSEP)}
+		}
+		return nil
+	}
+
+	var problems []string
+	if branchSEP != "" && number != branchSEP {
+		problems = append(problems, fmt.Sprintf("SEP-%s does not match the branch, which is for SEP-%s", number, branchSEP))
+	}
+
+	st, exists := status(number)
+	switch {
+	case !exists:
+		problems = append(problems, fmt.Sprintf("SEP-%s does not exist", number))
+	case IsTerminal(st):
+		problems = append(problems, fmt.Sprintf("SEP-%s is %s; open a new SEP for further work", number, st))
+	}
+	return problems
+}
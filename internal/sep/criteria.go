@@ -0,0 +1,224 @@
+package sep
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Criterion is a "- [ ]" line of the Done When section. HTML comments on
+// the line carry annotations and are not part of Text, e.g.
+//
+//   - [x] Users can log in <!-- checked: @alice 2025-01-20 -->
+//   - [ ] Login is rate-limited <!-- verify: go test ./auth -run TestRateLimit -->
+type Criterion struct {
+	Text      string // without annotations
+	Checked   bool
+	CheckedBy string // who checked it, if recorded
+	CheckedAt string // YYYY-MM-DD it was checked, if recorded
+	Verify    string // shell command that proves the criterion, if any
+
+	index int // line index within the section body
+}
+
+var (
+	// commentRe matches an HTML comment and the whitespace before it
+	commentRe = regexp.MustCompile(`\s*<!--(.*?)-->`)
+
+	// checkedNoteRe parses the body of a "checked:" comment
+	checkedNoteRe = regexp.MustCompile(`^\s*checked:\s*(?:(.*?)\s+)?(\d{4}-\d{2}-\d{2})\s*$`)
+
+	// verifyNoteRe parses the body of a "verify:" comment
+	verifyNoteRe = regexp.MustCompile(`^\s*verify:\s*(.*?)\s*$`)
+)
+
+// parseCriterion parses one line of the Done When section. Template
+// placeholders such as "- [ ] [Acceptance criteria 1]" are not criteria.
+func parseCriterion(line string) (Criterion, bool) {
+	if !strings.HasPrefix(line, "- [") || len(line) < 5 {
+		return Criterion{}, false
+	}
+
+	c := Criterion{Checked: strings.HasPrefix(line, "- [x]") || strings.HasPrefix(line, "- [X]")}
+	rest := line[5:]
+	for _, m := range commentRe.FindAllStringSubmatch(rest, -1) {
+		if note := checkedNoteRe.FindStringSubmatch(m[1]); note != nil {
+			c.CheckedBy, c.CheckedAt = note[1], note[2]
+		} else if note := verifyNoteRe.FindStringSubmatch(m[1]); note != nil {
+			c.Verify = note[1]
+		}
+	}
+	c.Text = strings.TrimSpace(commentRe.ReplaceAllString(rest, ""))
+
+	if c.Text == "" || strings.HasPrefix(c.Text, "[") {
+		return Criterion{}, false
+	}
+	return c, true
+}
+
+// ParseCriteria returns the criteria in the body of a Done When section
+func ParseCriteria(body string) []Criterion {
+	var criteria []Criterion
+	for i, line := range strings.Split(body, "\n") {
+		if c, ok := parseCriterion(line); ok {
+			c.index = i
+			criteria = append(criteria, c)
+		}
+	}
+	return criteria
+}
+
+// Criteria returns the Done When criteria of s, numbered like DoneWhen
+func (s *SEP) Criteria() []Criterion {
+	section := s.Section(SectionDoneWhen)
+	if section == nil {
+		return nil
+	}
+	return ParseCriteria(section.Body)
+}
+
+// CheckCriterion checks criterion n (1-based), recording who checked it
+// and when. by may be empty.
+func (s *SEP) CheckCriterion(n int, by, date string) error {
+	return s.editCriterion(n, func(line string) string {
+		line = setCheckbox(removeNote(line, checkedNoteRe), 'x')
+		note := strings.TrimSpace(by + " " + date)
+		return fmt.Sprintf("%s <!-- checked: %s -->", line, note)
+	})
+}
+
+// UncheckCriterion unchecks criterion n (1-based) and drops its checked
+// note
+func (s *SEP) UncheckCriterion(n int) error {
+	return s.editCriterion(n, func(line string) string {
+		return setCheckbox(removeNote(line, checkedNoteRe), ' ')
+	})
+}
+
+// RemoveCriterion deletes criterion n (1-based). Later criteria move up
+// one number, so a number read before another edit may point elsewhere:
+// when text is not empty, the criterion must have that text or nothing is
+// removed.
+func (s *SEP) RemoveCriterion(n int, text string) error {
+	return s.editCriteria(func(lines []string, criteria []Criterion) ([]string, error) {
+		c, err := criterionAt(criteria, n)
+		if err != nil {
+			return nil, err
+		}
+		if text = strings.TrimSpace(text); text != "" && text != c.Text {
+			return nil, fmt.Errorf("criterion #%d is %q, not %q", n, c.Text, text)
+		}
+		return append(lines[:c.index:c.index], lines[c.index+1:]...), nil
+	})
+}
+
+// AddCriterion appends an unchecked criterion after the last one, creating
+// the Done When section if needed
+func (s *SEP) AddCriterion(text string) error {
+	text = strings.TrimSpace(text)
+	if err := ValidateCriterion(text); err != nil {
+		return err
+	}
+
+	return s.editCriteria(func(lines []string, criteria []Criterion) ([]string, error) {
+		line := "- [ ] " + text
+		if len(criteria) == 0 {
+			return append(lines, line), nil
+		}
+		at := criteria[len(criteria)-1].index + 1
+		return append(lines[:at:at], append([]string{line}, lines[at:]...)...), nil
+	})
+}
+
+// ValidateCriterion checks text can be written as a "- [ ]" line
+func ValidateCriterion(text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.Contains(text, "\n") {
+		return fmt.Errorf("a criterion must be a single non-empty line")
+	}
+	if _, ok := parseCriterion("- [ ] " + text); !ok {
+		return fmt.Errorf("a criterion cannot start with \"[\": %s", text)
+	}
+	return nil
+}
+
+// editCriterion rewrites the line of criterion n (1-based) with fn
+func (s *SEP) editCriterion(n int, fn func(line string) string) error {
+	return s.editCriteria(func(lines []string, criteria []Criterion) ([]string, error) {
+		c, err := criterionAt(criteria, n)
+		if err != nil {
+			return nil, err
+		}
+		lines[c.index] = fn(lines[c.index])
+		return lines, nil
+	})
+}
+
+// editCriteria rewrites the lines of the Done When section with fn and
+// writes the file. Only the section's body lines are spliced back, so the
+// blank lines around it and everything else stay as they are.
+func (s *SEP) editCriteria(fn func(lines []string, criteria []Criterion) ([]string, error)) error {
+	doc, err := LoadDocument(s.FilePath)
+	if err != nil {
+		return err
+	}
+
+	section := doc.Section(SectionDoneWhen)
+	var lines []string
+	if section != nil && section.Body != "" {
+		lines = strings.Split(section.Body, "\n")
+	}
+
+	lines, err = fn(lines, ParseCriteria(strings.Join(lines, "\n")))
+	if err != nil {
+		return err
+	}
+
+	// A missing or empty section is laid out by SetSection
+	if section != nil && section.Body != "" {
+		err = doc.ReplaceBody(SectionDoneWhen, lines)
+	} else {
+		err = doc.SetSection(SectionDoneWhen, strings.Join(lines, "\n"))
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.FilePath, doc.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	s.Sections = doc.Sections
+	s.DoneWhen, s.DoneWhenStatus = nil, nil
+	for _, c := range s.Criteria() {
+		s.DoneWhen = append(s.DoneWhen, c.Text)
+		s.DoneWhenStatus = append(s.DoneWhenStatus, c.Checked)
+	}
+	return nil
+}
+
+// criterionAt returns criterion n (1-based)
+func criterionAt(criteria []Criterion, n int) (Criterion, error) {
+	if n < 1 || n > len(criteria) {
+		if len(criteria) == 0 {
+			return Criterion{}, fmt.Errorf("no Done When criteria")
+		}
+		return Criterion{}, fmt.Errorf("no criterion #%d (valid: 1-%d)", n, len(criteria))
+	}
+	return criteria[n-1], nil
+}
+
+// setCheckbox sets the mark of a "- [ ]" line
+func setCheckbox(line string, mark byte) string {
+	return line[:3] + string(mark) + line[4:]
+}
+
+// removeNote drops the HTML comments on line whose body matches re
+func removeNote(line string, re *regexp.Regexp) string {
+	return commentRe.ReplaceAllStringFunc(line, func(comment string) string {
+		if re.MatchString(commentRe.FindStringSubmatch(comment)[1]) {
+			return ""
+		}
+		return comment
+	})
+}
@@ -0,0 +1,273 @@
+package sep
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Section headings used by the SEP template
+const (
+	SectionWhatAndWhy          = "What & Why"
+	SectionDoneWhen            = "Done When"
+	SectionPlan                = "Plan"
+	SectionImplementationNotes = "Implementation Notes"
+)
+
+// Section is a "## " section of a SEP document
+type Section struct {
+	Heading   string // e.g., "Plan"
+	Body      string // Raw content, without the heading and trailing "---" separator
+	Line      int    // 1-based line of the heading
+	StartLine int    // 1-based first line of Body
+	EndLine   int    // 1-based last line of Body (StartLine-1 when empty)
+}
+
+// Document is a SEP file split into frontmatter, title and ordered sections.
+// Line numbers are 1-based and refer to the file as a whole.
+type Document struct {
+	Frontmatter     string     // Raw YAML between the "---" delimiters
+	FrontmatterLine int        // Line of the first frontmatter line (0 if none)
+	Title           string     // Text of the "# " heading
+	TitleLine       int        // Line of the "# " heading (0 if none)
+	Sections        []*Section // "## " sections in file order
+
+	lines []string
+}
+
+// ParseDocument splits SEP file content into its document model
+func ParseDocument(content []byte) (*Document, error) {
+	doc := &Document{lines: strings.Split(string(content), "\n")}
+	if err := doc.index(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// LoadDocument reads and parses the SEP file at filePath
+func LoadDocument(filePath string) (*Document, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDocument(content)
+}
+
+// index (re)computes frontmatter, title and sections from doc.lines
+func (d *Document) index() error {
+	d.Frontmatter = ""
+	d.FrontmatterLine = 0
+	d.Title = ""
+	d.TitleLine = 0
+	d.Sections = nil
+
+	i := 0
+	if len(d.lines) > 0 && isDelimiter(d.lines[0]) {
+		end := -1
+		for j := 1; j < len(d.lines); j++ {
+			if isDelimiter(d.lines[j]) {
+				end = j
+				break
+			}
+		}
+		if end < 0 {
+			return fmt.Errorf("line 1: frontmatter is missing its closing %q", frontmatterDelimiter)
+		}
+		d.Frontmatter = strings.Join(d.lines[1:end], "\n")
+		d.FrontmatterLine = 2
+		i = end + 1
+	}
+
+	var current *Section
+	inFence := false
+	for ; i < len(d.lines); i++ {
+		line := strings.TrimRight(d.lines[i], "\r")
+
+		// Headings inside fenced code blocks are content
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if after, found := strings.CutPrefix(line, "# "); found && d.TitleLine == 0 && current == nil {
+			d.Title = strings.TrimSpace(after)
+			d.TitleLine = i + 1
+			continue
+		}
+
+		if after, found := strings.CutPrefix(line, "## "); found {
+			if current != nil {
+				d.closeSection(current, i)
+			}
+			current = &Section{Heading: strings.TrimSpace(after), Line: i + 1}
+			d.Sections = append(d.Sections, current)
+		}
+	}
+	if current != nil {
+		d.closeSection(current, len(d.lines))
+	}
+
+	return nil
+}
+
+// closeSection fills in the body of s, which ends before line index next
+func (d *Document) closeSection(s *Section, next int) {
+	start, end := d.bodyRange(s.Line, next)
+	s.StartLine = start + 1
+	s.EndLine = end
+	s.Body = strings.Join(d.lines[start:end], "\n")
+}
+
+// bodyRange returns the [start, end) line indexes of a section's content,
+// skipping leading blank lines and the trailing blank lines and "---"
+// separators that sit between sections
+func (d *Document) bodyRange(headingLine, next int) (int, int) {
+	start := headingLine
+	for start < next && strings.TrimSpace(d.lines[start]) == "" {
+		start++
+	}
+
+	end := next
+	for end > start {
+		trimmed := strings.TrimSpace(d.lines[end-1])
+		if trimmed != "" && trimmed != frontmatterDelimiter {
+			break
+		}
+		end--
+	}
+
+	return start, end
+}
+
+// Section returns the section with the given heading (case-insensitive),
+// or nil if the document has no such section
+func (d *Document) Section(heading string) *Section {
+	for _, s := range d.Sections {
+		if strings.EqualFold(s.Heading, heading) {
+			return s
+		}
+	}
+	return nil
+}
+
+// SetSection replaces the body of the section with the given heading,
+// appending a new section at the end of the document if it does not exist.
+// Surrounding separators and all other sections are left untouched.
+func (d *Document) SetSection(heading, body string) error {
+	bodyLines := strings.Split(strings.TrimRight(body, "\n"), "\n")
+	if strings.TrimSpace(body) == "" {
+		bodyLines = nil
+	}
+
+	s := d.Section(heading)
+	if s == nil {
+		end := len(d.lines)
+		for end > 0 && strings.TrimSpace(d.lines[end-1]) == "" {
+			end--
+		}
+		added := []string{"", "## " + heading}
+		if len(bodyLines) > 0 {
+			added = append(added, "")
+			added = append(added, bodyLines...)
+		}
+		added = append(added, "")
+		d.lines = spliceLines(d.lines, end, len(d.lines), added)
+		return d.index()
+	}
+
+	// Replace everything from the heading to the end of the content,
+	// keeping whatever separates this section from the next one
+	start := s.Line
+	end := s.EndLine
+	if end < start {
+		end = start
+	}
+
+	repl := []string{""}
+	repl = append(repl, bodyLines...)
+	if len(bodyLines) == 0 {
+		repl = nil
+	}
+	if end < len(d.lines) && strings.TrimSpace(d.lines[end]) != "" {
+		repl = append(repl, "")
+	}
+
+	d.lines = spliceLines(d.lines, start, end, repl)
+	return d.index()
+}
+
+// ReplaceBody replaces the body lines of the section with the given heading,
+// StartLine to EndLine, with lines. Unlike SetSection it leaves the lines
+// around the body as they are. The section must exist.
+func (d *Document) ReplaceBody(heading string, lines []string) error {
+	s := d.Section(heading)
+	if s == nil {
+		return fmt.Errorf("no %q section", heading)
+	}
+	d.lines = spliceLines(d.lines, s.StartLine-1, s.EndLine, lines)
+	return d.index()
+}
+
+// Bytes returns the full document content
+func (d *Document) Bytes() []byte {
+	return []byte(strings.Join(d.lines, "\n"))
+}
+
+// isDelimiter reports whether line is a frontmatter delimiter
+func isDelimiter(line string) bool {
+	return strings.TrimRight(line, "\r") == frontmatterDelimiter
+}
+
+// UpdateSection rewrites one section of the SEP file, creating it if needed
+func (s *SEP) UpdateSection(heading, body string) error {
+	doc, err := LoadDocument(s.FilePath)
+	if err != nil {
+		return err
+	}
+
+	if err := doc.SetSection(heading, body); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(s.FilePath, doc.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	s.Sections = doc.Sections
+	return nil
+}
+
+// Section returns the section of the SEP with the given heading, or nil
+func (s *SEP) Section(heading string) *Section {
+	for _, sec := range s.Sections {
+		if strings.EqualFold(sec.Heading, heading) {
+			return sec
+		}
+	}
+	return nil
+}
+
+// HasContent reports whether the section holds more than template
+// placeholders such as "[...]" and italic hints like "*Added during ...*"
+func (sec *Section) HasContent() bool {
+	for _, line := range strings.Split(sec.Body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || placeholderRe.MatchString(line) {
+			continue
+		}
+		if len(line) > 2 && strings.HasPrefix(line, "*") && strings.HasSuffix(line, "*") && !strings.HasPrefix(line, "**") {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// HasPlan reports whether the Plan section has been written
+func (s *SEP) HasPlan() bool {
+	section := s.Section(SectionPlan)
+	return section != nil && section.HasContent()
+}
@@ -0,0 +1,247 @@
+package sep
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontmatterDelimiter opens and closes the YAML frontmatter block
+const frontmatterDelimiter = "---"
+
+// FrontmatterEditor edits the YAML frontmatter of a SEP file in place.
+//
+// Only the lines belonging to a key that is set or deleted are rewritten.
+// Comments, key order, formatting and keys unknown to Frontmatter are left
+// byte-for-byte intact, as is everything after the closing delimiter.
+type FrontmatterEditor struct {
+	lines []string // frontmatter lines, without delimiters
+	body  string   // content after the closing delimiter, verbatim
+}
+
+// NewFrontmatterEditor prepares the frontmatter of a SEP file for editing
+func NewFrontmatterEditor(content []byte) (*FrontmatterEditor, error) {
+	lines, body, err := splitFrontmatter(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	e := &FrontmatterEditor{lines: lines, body: body}
+	if _, err := e.mapping(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// splitFrontmatter separates the frontmatter lines from the rest of the file
+func splitFrontmatter(content string) ([]string, string, error) {
+	first, rest, found := strings.Cut(content, "\n")
+	if !found || strings.TrimRight(first, "\r") != frontmatterDelimiter {
+		return nil, "", fmt.Errorf("invalid frontmatter format: file must start with %q", frontmatterDelimiter)
+	}
+
+	var lines []string
+	for {
+		line, next, more := strings.Cut(rest, "\n")
+		if strings.TrimRight(line, "\r") == frontmatterDelimiter {
+			body := ""
+			if more {
+				body = "\n" + next
+			}
+			return lines, line + body, nil
+		}
+		if !more {
+			return nil, "", fmt.Errorf("invalid frontmatter format: missing closing %q", frontmatterDelimiter)
+		}
+		lines = append(lines, line)
+		rest = next
+	}
+}
+
+// mapping parses the current frontmatter into its top-level mapping node
+func (e *FrontmatterEditor) mapping() (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(e.lines, "\n")), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+
+	// An empty frontmatter block is an empty mapping
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode}, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed to parse frontmatter: expected a mapping")
+	}
+	return root, nil
+}
+
+// find returns the key and value nodes for key, or nil if it is not present
+func (e *FrontmatterEditor) find(key string) (keyNode, valueNode *yaml.Node, err error) {
+	root, err := e.mapping()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			return root.Content[i], root.Content[i+1], nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// entryRange returns the [start, end) line indexes occupied by the entry
+// whose key starts on the given 1-based line
+func (e *FrontmatterEditor) entryRange(keyLine int) (int, int) {
+	start := keyLine - 1
+	end := start + 1
+
+	// Block values continue on indented lines, or on "- " lines for
+	// sequences written at column zero
+	for end < len(e.lines) {
+		line := e.lines[end]
+		if strings.TrimSpace(line) == "" ||
+			strings.HasPrefix(line, " ") ||
+			strings.HasPrefix(line, "\t") ||
+			strings.HasPrefix(line, "- ") ||
+			line == "-" {
+			end++
+			continue
+		}
+		break
+	}
+
+	// Trailing blank lines separate entries and belong to neither
+	for end > start+1 && strings.TrimSpace(e.lines[end-1]) == "" {
+		end--
+	}
+
+	return start, end
+}
+
+// Has reports whether key is present in the frontmatter
+func (e *FrontmatterEditor) Has(key string) bool {
+	keyNode, _, err := e.find(key)
+	return err == nil && keyNode != nil
+}
+
+// Get decodes the value of key into out, reporting whether the key exists
+func (e *FrontmatterEditor) Get(key string, out interface{}) (bool, error) {
+	keyNode, valueNode, err := e.find(key)
+	if err != nil || keyNode == nil {
+		return false, err
+	}
+	if err := valueNode.Decode(out); err != nil {
+		return true, fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// Set replaces the value of key, or appends the key if it is missing.
+//
+// The existing quoting style, flow/block style and line comments of the
+// entry are kept where the new value allows it.
+func (e *FrontmatterEditor) Set(key string, value interface{}) error {
+	keyNode, valueNode, err := e.find(key)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := renderEntry(key, keyNode, valueNode, value)
+	if err != nil {
+		return err
+	}
+
+	if keyNode == nil {
+		// Append after the last non-blank line
+		end := len(e.lines)
+		for end > 0 && strings.TrimSpace(e.lines[end-1]) == "" {
+			end--
+		}
+		e.lines = spliceLines(e.lines, end, end, rendered)
+		return nil
+	}
+
+	start, end := e.entryRange(keyNode.Line)
+	e.lines = spliceLines(e.lines, start, end, rendered)
+	return nil
+}
+
+// Delete removes key and its value, if present
+func (e *FrontmatterEditor) Delete(key string) error {
+	keyNode, _, err := e.find(key)
+	if err != nil || keyNode == nil {
+		return err
+	}
+
+	start, end := e.entryRange(keyNode.Line)
+	e.lines = spliceLines(e.lines, start, end, nil)
+	return nil
+}
+
+// Bytes returns the full file content with the edited frontmatter
+func (e *FrontmatterEditor) Bytes() []byte {
+	var b strings.Builder
+	b.WriteString(frontmatterDelimiter)
+	b.WriteString("\n")
+	for _, line := range e.lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString(e.body)
+	return []byte(b.String())
+}
+
+// renderEntry renders "key: value" as YAML lines, carrying over the style
+// and comments of the entry being replaced
+func renderEntry(key string, oldKey, oldValue *yaml.Node, value interface{}) ([]string, error) {
+	newValue := &yaml.Node{}
+	if err := newValue.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	newKey := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+
+	if oldKey != nil {
+		newKey.LineComment = oldKey.LineComment
+		newValue.LineComment = oldValue.LineComment
+
+		if newValue.Kind == oldValue.Kind {
+			switch newValue.Kind {
+			case yaml.ScalarNode:
+				quoted := oldValue.Style & (yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle)
+				if quoted != 0 && newValue.Tag == "!!str" && !strings.Contains(newValue.Value, "\n") {
+					newValue.Style = quoted
+				}
+			case yaml.SequenceNode, yaml.MappingNode:
+				newValue.Style = oldValue.Style & yaml.FlowStyle
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	entry := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{newKey, newValue}}
+	if err := enc.Encode(entry); err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", key, err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", key, err)
+	}
+
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"), nil
+}
+
+// spliceLines replaces lines[start:end] with repl
+func spliceLines(lines []string, start, end int, repl []string) []string {
+	out := make([]string, 0, len(lines)-(end-start)+len(repl))
+	out = append(out, lines[:start]...)
+	out = append(out, repl...)
+	out = append(out, lines[end:]...)
+	return out
+}
@@ -0,0 +1,290 @@
+package sep
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Area patterns are slash-separated globs relative to the repository root:
+//
+//	*       any run of characters within one path segment
+//	?       any single character within one path segment
+//	[a-z]   a character class; [!a-z] or [^a-z] negates it
+//	**      zero or more whole path segments
+//	\x      the literal character x
+//
+// A pattern also covers everything below the paths it matches, so
+// "internal/auth" and "internal/auth/*" both cover
+// "internal/auth/jwt/token.go", unless its last segment has a file
+// extension ("main.go", "*.sql"). Use "**/*.sql" to match at any depth and
+// an explicit "/**" for directories with a dot in their name.
+
+// MatchArea reports whether the area pattern covers path
+func MatchArea(pattern, path string) bool {
+	p := compileArea(pattern)
+	if p == nil {
+		return false
+	}
+	return segmentsIntersect(p, compilePath(path))
+}
+
+// AreasOverlap reports whether some path is covered by both area patterns
+func AreasOverlap(a, b string) bool {
+	pa, pb := compileArea(a), compileArea(b)
+	if pa == nil || pb == nil {
+		return false
+	}
+	return segmentsIntersect(pa, pb)
+}
+
+// globSegment is one compiled path segment
+type globSegment struct {
+	anyDepth bool        // "**": zero or more segments
+	tokens   []globToken // character tokens otherwise
+}
+
+// globToken is one compiled character of a segment pattern
+type globToken struct {
+	kind    tokenKind
+	ch      rune      // tokenLiteral
+	ranges  [][2]rune // tokenClass, inclusive ranges
+	negated bool      // tokenClass
+}
+
+type tokenKind int
+
+const (
+	tokenLiteral tokenKind = iota
+	tokenAny               // ?
+	tokenStar              // *
+	tokenClass             // [...]
+)
+
+// normalizeArea trims whitespace, "./" prefixes and redundant slashes
+func normalizeArea(s string) string {
+	s = strings.TrimSpace(s)
+	for strings.HasPrefix(s, "./") {
+		s = s[2:]
+	}
+	s = strings.Trim(s, "/")
+	for strings.Contains(s, "//") {
+		s = strings.ReplaceAll(s, "//", "/")
+	}
+	return s
+}
+
+// compileArea compiles an area pattern, appending the implicit "**" that
+// makes it cover everything below the directories it matches
+func compileArea(pattern string) []globSegment {
+	pattern = normalizeArea(pattern)
+	if pattern == "" {
+		return nil
+	}
+
+	parts := strings.Split(pattern, "/")
+	var segments []globSegment
+	for _, part := range parts {
+		if part == "**" {
+			segments = append(segments, globSegment{anyDepth: true})
+			continue
+		}
+		segments = append(segments, globSegment{tokens: compileSegment(part)})
+	}
+	if last := parts[len(parts)-1]; strings.Contains(strings.TrimPrefix(last, "."), ".") {
+		return segments
+	}
+	return append(segments, globSegment{anyDepth: true})
+}
+
+// compilePath compiles a concrete path, whose characters are all literal
+func compilePath(path string) []globSegment {
+	var segments []globSegment
+	for _, part := range strings.Split(normalizeArea(path), "/") {
+		var tokens []globToken
+		for _, r := range part {
+			tokens = append(tokens, globToken{kind: tokenLiteral, ch: r})
+		}
+		segments = append(segments, globSegment{tokens: tokens})
+	}
+	return segments
+}
+
+// compileSegment compiles the glob syntax of a single path segment. A "["
+// without a closing "]" is taken literally.
+func compileSegment(s string) []globToken {
+	var tokens []globToken
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch r {
+		case '*':
+			// Consecutive stars are equivalent to one
+			if len(tokens) == 0 || tokens[len(tokens)-1].kind != tokenStar {
+				tokens = append(tokens, globToken{kind: tokenStar})
+			}
+		case '?':
+			tokens = append(tokens, globToken{kind: tokenAny})
+		case '\\':
+			if i+size < len(s) {
+				i += size
+				r, size = utf8.DecodeRuneInString(s[i:])
+			}
+			tokens = append(tokens, globToken{kind: tokenLiteral, ch: r})
+		case '[':
+			if class, n, ok := compileClass(s[i:]); ok {
+				tokens = append(tokens, class)
+				i += n
+				continue
+			}
+			tokens = append(tokens, globToken{kind: tokenLiteral, ch: r})
+		default:
+			tokens = append(tokens, globToken{kind: tokenLiteral, ch: r})
+		}
+		i += size
+	}
+	return tokens
+}
+
+// compileClass compiles a "[...]" class at the start of s, returning the
+// token and the number of bytes consumed
+func compileClass(s string) (globToken, int, bool) {
+	tok := globToken{kind: tokenClass}
+	i := 1
+	if i < len(s) && (s[i] == '!' || s[i] == '^') {
+		tok.negated = true
+		i++
+	}
+
+	first := true
+	for i < len(s) {
+		if s[i] == ']' && !first {
+			return tok, i + 1, true
+		}
+		first = false
+
+		lo, size := classRune(s[i:])
+		i += size
+		hi := lo
+		if i+1 < len(s) && s[i] == '-' && s[i+1] != ']' {
+			hi, size = classRune(s[i+1:])
+			i += 1 + size
+		}
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		tok.ranges = append(tok.ranges, [2]rune{lo, hi})
+	}
+
+	return globToken{}, 0, false
+}
+
+// classRune decodes one possibly escaped character inside a class
+func classRune(s string) (rune, int) {
+	if s[0] == '\\' && len(s) > 1 {
+		r, size := utf8.DecodeRuneInString(s[1:])
+		return r, size + 1
+	}
+	return utf8.DecodeRuneInString(s)
+}
+
+// segmentsIntersect reports whether some path matches both segment lists
+func segmentsIntersect(a, b []globSegment) bool {
+	memo := make(map[[2]int]bool)
+	var visit func(i, j int) bool
+	visit = func(i, j int) bool {
+		key := [2]int{i, j}
+		if result, ok := memo[key]; ok {
+			return result
+		}
+		memo[key] = false // guards against revisiting while in progress
+
+		var result bool
+		switch {
+		case i < len(a) && a[i].anyDepth:
+			// Match zero segments, or absorb one segment of b
+			result = visit(i+1, j) || (j < len(b) && visit(i, j+1))
+		case j < len(b) && b[j].anyDepth:
+			result = visit(i, j+1) || (i < len(a) && visit(i+1, j))
+		case i == len(a) || j == len(b):
+			result = i == len(a) && j == len(b)
+		default:
+			result = tokensIntersect(a[i].tokens, b[j].tokens) && visit(i+1, j+1)
+		}
+
+		memo[key] = result
+		return result
+	}
+	return visit(0, 0)
+}
+
+// tokensIntersect reports whether some string matches both segment patterns
+func tokensIntersect(a, b []globToken) bool {
+	memo := make(map[[2]int]bool)
+	var visit func(i, j int) bool
+	visit = func(i, j int) bool {
+		key := [2]int{i, j}
+		if result, ok := memo[key]; ok {
+			return result
+		}
+		memo[key] = false
+
+		var result bool
+		switch {
+		case i < len(a) && a[i].kind == tokenStar:
+			// Match nothing, or absorb one token of b
+			result = visit(i+1, j) || (j < len(b) && visit(i, j+1))
+		case j < len(b) && b[j].kind == tokenStar:
+			result = visit(i, j+1) || (i < len(a) && visit(i+1, j))
+		case i == len(a) || j == len(b):
+			result = i == len(a) && j == len(b)
+		default:
+			result = charsIntersect(a[i], b[j]) && visit(i+1, j+1)
+		}
+
+		memo[key] = result
+		return result
+	}
+	return visit(0, 0)
+}
+
+// charsIntersect reports whether some character matches both tokens.
+// Both character sets are unions of ranges, so if they intersect they
+// share a range endpoint, a neighbor of one, or an extreme rune.
+func charsIntersect(a, b globToken) bool {
+	candidates := []rune{1, utf8.MaxRune}
+	for _, tok := range []globToken{a, b} {
+		switch tok.kind {
+		case tokenLiteral:
+			candidates = append(candidates, tok.ch)
+		case tokenClass:
+			for _, r := range tok.ranges {
+				candidates = append(candidates, r[0], r[1], r[0]-1, r[1]+1)
+			}
+		}
+	}
+
+	for _, c := range candidates {
+		if c > 0 && c != '/' && a.matches(c) && b.matches(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether a single-character token matches r
+func (t globToken) matches(r rune) bool {
+	switch t.kind {
+	case tokenLiteral:
+		return t.ch == r
+	case tokenClass:
+		in := false
+		for _, rng := range t.ranges {
+			if r >= rng[0] && r <= rng[1] {
+				in = true
+				break
+			}
+		}
+		return in != t.negated
+	default:
+		return true
+	}
+}
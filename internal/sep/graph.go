@@ -0,0 +1,271 @@
+package sep
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Graph is the dependency graph of a set of SEPs, with edges from each
+// SEP to the SEPs listed in its depends_on
+type Graph struct {
+	nodes      map[string]*SEP
+	deps       map[string][]string // number -> depends_on
+	dependents map[string][]string // number -> SEPs depending on it
+	numbers    []string            // all node numbers, sorted
+}
+
+// MissingDependency is a depends_on entry that references no known SEP
+type MissingDependency struct {
+	SEP        *SEP
+	Dependency string
+}
+
+// NewGraph builds the dependency graph of the given SEPs. If several SEPs
+// share a number, the first one wins.
+func NewGraph(seps []*SEP) *Graph {
+	g := &Graph{
+		nodes:      make(map[string]*SEP),
+		deps:       make(map[string][]string),
+		dependents: make(map[string][]string),
+	}
+
+	for _, s := range seps {
+		if g.nodes[s.Number] != nil {
+			continue
+		}
+		g.nodes[s.Number] = s
+		g.numbers = append(g.numbers, s.Number)
+	}
+	sort.Strings(g.numbers)
+
+	for _, number := range g.numbers {
+		for _, dep := range g.nodes[number].DependsOn {
+			if contains(g.deps[number], dep) {
+				continue
+			}
+			g.deps[number] = append(g.deps[number], dep)
+			g.dependents[dep] = append(g.dependents[dep], number)
+		}
+	}
+
+	return g
+}
+
+// Node returns the SEP with the given number, or nil
+func (g *Graph) Node(number string) *SEP {
+	return g.nodes[number]
+}
+
+// Missing returns depends_on entries that reference unknown SEPs
+func (g *Graph) Missing() []MissingDependency {
+	var missing []MissingDependency
+	for _, number := range g.numbers {
+		for _, dep := range g.deps[number] {
+			if g.nodes[dep] == nil {
+				missing = append(missing, MissingDependency{SEP: g.nodes[number], Dependency: dep})
+			}
+		}
+	}
+	return missing
+}
+
+// Cycles returns one dependency cycle per strongly connected component of
+// the graph (Tarjan's algorithm). Each cycle starts at its lowest number
+// and follows depends_on edges until it would return to the start.
+func (g *Graph) Cycles() [][]string {
+	index := 0
+	indexes := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var visit func(n string)
+	visit = func(n string) {
+		indexes[n] = index
+		lowlink[n] = index
+		index++
+		stack = append(stack, n)
+		onStack[n] = true
+
+		for _, dep := range g.deps[n] {
+			if g.nodes[dep] == nil {
+				continue
+			}
+			if _, seen := indexes[dep]; !seen {
+				visit(dep)
+				lowlink[n] = min(lowlink[n], lowlink[dep])
+			} else if onStack[dep] {
+				lowlink[n] = min(lowlink[n], indexes[dep])
+			}
+		}
+
+		if lowlink[n] != indexes[n] {
+			return
+		}
+
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == n {
+				break
+			}
+		}
+
+		// A single node is only a cycle if it depends on itself
+		if len(component) > 1 || contains(g.deps[n], n) {
+			cycles = append(cycles, g.cyclePath(component))
+		}
+	}
+
+	for _, n := range g.numbers {
+		if _, seen := indexes[n]; !seen {
+			visit(n)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// TopologicalOrder returns all SEP numbers ordered so that every SEP comes
+// after its dependencies, breaking ties by number. It fails if the graph
+// has cycles.
+func (g *Graph) TopologicalOrder() ([]string, error) {
+	if cycles := g.Cycles(); len(cycles) > 0 {
+		return nil, fmt.Errorf("dependency cycle: %s", FormatCycle(cycles[0]))
+	}
+
+	pending := make(map[string]int)
+	for _, n := range g.numbers {
+		for _, dep := range g.deps[n] {
+			if g.nodes[dep] != nil {
+				pending[n]++
+			}
+		}
+	}
+
+	var ready []string
+	for _, n := range g.numbers {
+		if pending[n] == 0 {
+			ready = append(ready, n)
+		}
+	}
+
+	var order []string
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		n := ready[0]
+		ready = ready[1:]
+		order = append(order, n)
+
+		for _, dependent := range g.dependents[n] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	return order, nil
+}
+
+// Dependencies returns every SEP number that number depends on, directly
+// or transitively, sorted
+func (g *Graph) Dependencies(number string) []string {
+	return g.reach(number, g.deps)
+}
+
+// Dependents returns every SEP number that depends on number, directly or
+// transitively, sorted
+func (g *Graph) Dependents(number string) []string {
+	return g.reach(number, g.dependents)
+}
+
+// Blockers returns the transitive dependencies of number that are not done,
+// including references to unknown SEPs, sorted
+func (g *Graph) Blockers(number string) []string {
+	var blockers []string
+	for _, dep := range g.Dependencies(number) {
+		if s := g.nodes[dep]; s == nil || !IsDone(s.Status) {
+			blockers = append(blockers, dep)
+		}
+	}
+	return blockers
+}
+
+// cyclePath finds a cycle through the lowest number of a strongly
+// connected component, using breadth-first search inside the component
+func (g *Graph) cyclePath(component []string) []string {
+	sort.Strings(component)
+	start := component[0]
+	inComponent := make(map[string]bool)
+	for _, n := range component {
+		inComponent[n] = true
+	}
+
+	parent := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, dep := range g.deps[n] {
+			if dep == start {
+				path := []string{n}
+				for n != start {
+					n = parent[n]
+					path = append(path, n)
+				}
+				// Reverse into start → ... → n order
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if _, seen := parent[dep]; !seen && inComponent[dep] {
+				parent[dep] = n
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	return component
+}
+
+// reach walks edges from number and returns every node reached, sorted
+func (g *Graph) reach(number string, edges map[string][]string) []string {
+	seen := map[string]bool{number: true}
+	queue := append([]string(nil), edges[number]...)
+	var reached []string
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		reached = append(reached, n)
+		queue = append(queue, edges[n]...)
+	}
+
+	sort.Strings(reached)
+	return reached
+}
+
+// FormatCycle renders a cycle as "SEP-0001 → SEP-0002 → SEP-0001"
+func FormatCycle(cycle []string) string {
+	if len(cycle) == 0 {
+		return ""
+	}
+	ids := make([]string, 0, len(cycle)+1)
+	for _, n := range cycle {
+		ids = append(ids, "SEP-"+n)
+	}
+	ids = append(ids, "SEP-"+cycle[0])
+	return strings.Join(ids, " → ")
+}
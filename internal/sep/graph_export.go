@@ -0,0 +1,174 @@
+package sep
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultNodeColor fills nodes whose status has no color
+const defaultNodeColor = "#ffffff"
+
+// ExportOptions controls how a dependency graph is rendered
+type ExportOptions struct {
+	Conflicts []Conflict // Drawn as dashed, undirected edges when non-empty
+}
+
+// DOT renders the graph in Graphviz DOT format. Edges point from a
+// dependency to the SEPs that depend on it; nodes are filled with the color
+// of their status and labeled with the assigned pilot.
+func (g *Graph) DOT(opts ExportOptions) string {
+	var b strings.Builder
+
+	b.WriteString("digraph seps {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	for _, number := range g.numbers {
+		s := g.nodes[number]
+		fmt.Fprintf(&b, "  %s [label=\"%s\", fillcolor=\"%s\"];\n",
+			nodeID(number), dotEscape(strings.Join(nodeLabel(s), "\n")), statusColor(s.Status))
+	}
+	for _, dep := range g.missingNumbers() {
+		fmt.Fprintf(&b, "  %s [label=\"SEP-%s\\n(missing)\", style=dashed];\n", nodeID(dep), dep)
+	}
+
+	for _, number := range g.numbers {
+		for _, dep := range g.deps[number] {
+			fmt.Fprintf(&b, "  %s -> %s;\n", nodeID(dep), nodeID(number))
+		}
+	}
+
+	for _, c := range opts.Conflicts {
+		fmt.Fprintf(&b, "  %s -> %s [dir=none, constraint=false, style=dashed, color=\"#e53935\", label=\"%s\"];\n",
+			nodeID(c.SEP1.Number), nodeID(c.SEP2.Number), dotEscape(strings.Join(c.OverlapAreas, "\n")))
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart, with the same layout
+// conventions as DOT
+func (g *Graph) Mermaid(opts ExportOptions) string {
+	var b strings.Builder
+
+	b.WriteString("flowchart LR\n")
+
+	for _, number := range g.numbers {
+		s := g.nodes[number]
+		var lines []string
+		for _, line := range nodeLabel(s) {
+			lines = append(lines, mermaidEscape(line))
+		}
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", nodeID(number), strings.Join(lines, "<br/>"))
+	}
+	for _, dep := range g.missingNumbers() {
+		fmt.Fprintf(&b, "  %s[\"SEP-%s<br/>(missing)\"]\n", nodeID(dep), dep)
+	}
+
+	for _, number := range g.numbers {
+		for _, dep := range g.deps[number] {
+			fmt.Fprintf(&b, "  %s --> %s\n", nodeID(dep), nodeID(number))
+		}
+	}
+
+	for _, c := range opts.Conflicts {
+		fmt.Fprintf(&b, "  %s -.-|\"%s\"| %s\n",
+			nodeID(c.SEP1.Number), mermaidEscape(strings.Join(c.OverlapAreas, ", ")), nodeID(c.SEP2.Number))
+	}
+
+	// One class per status present in the graph
+	var classes []string
+	members := make(map[string][]string)
+	for _, number := range g.numbers {
+		status := g.nodes[number].Status
+		if _, seen := members[status]; !seen {
+			classes = append(classes, status)
+		}
+		members[status] = append(members[status], nodeID(number))
+	}
+	for _, status := range classes {
+		class := mermaidClass(status)
+		fmt.Fprintf(&b, "  classDef %s fill:%s\n", class, statusColor(status))
+		fmt.Fprintf(&b, "  class %s %s\n", strings.Join(members[status], ","), class)
+	}
+
+	return b.String()
+}
+
+// missingNumbers returns each missing dependency once, however many SEPs
+// depend on it
+func (g *Graph) missingNumbers() []string {
+	var numbers []string
+	seen := make(map[string]bool)
+	for _, m := range g.Missing() {
+		if !seen[m.Dependency] {
+			seen[m.Dependency] = true
+			numbers = append(numbers, m.Dependency)
+		}
+	}
+	return numbers
+}
+
+// nodeLabel returns the label lines of a SEP node
+func nodeLabel(s *SEP) []string {
+	lines := []string{s.ID(), s.Title}
+	if s.Assigned != "" {
+		lines = append(lines, s.Assigned)
+	}
+	return lines
+}
+
+// nodeID returns the graph node identifier for a SEP number
+func nodeID(number string) string {
+	return "sep" + number
+}
+
+// statusColor returns the fill color configured for a status
+func statusColor(status string) string {
+	if def, ok := LookupStatus(status); ok && def.Color != "" {
+		return def.Color
+	}
+	return defaultNodeColor
+}
+
+// dotEscape escapes a string for a double-quoted DOT attribute
+func dotEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return strings.ReplaceAll(s, "\n", `\n`)
+}
+
+// mermaidEscaper replaces the characters Mermaid labels treat as markup
+// with entity codes
+var mermaidEscaper = strings.NewReplacer(
+	"#", "#35;",
+	`"`, "#quot;",
+	"<", "#lt;",
+	">", "#gt;",
+	"&", "#amp;",
+)
+
+// mermaidEscape escapes a string for a double-quoted Mermaid label
+func mermaidEscape(s string) string {
+	return mermaidEscaper.Replace(s)
+}
+
+// mermaidClass turns a status into a valid Mermaid class name. Other
+// characters become "_<hex>_" and "_" becomes "__", so different statuses
+// never share a class.
+func mermaidClass(status string) string {
+	var b strings.Builder
+	b.WriteString("status_")
+	for _, r := range status {
+		switch {
+		case r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+		case r == '_':
+			b.WriteString("__")
+		default:
+			fmt.Fprintf(&b, "_%x_", r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,104 @@
+package sep
+
+import "fmt"
+
+// Kinds of Change
+const (
+	ChangeCreated            = "created"
+	ChangeStatus             = "status"
+	ChangeAssigned           = "assigned"
+	ChangeLease              = "lease"
+	ChangeCriterionChecked   = "criterion-checked"
+	ChangeCriterionUnchecked = "criterion-unchecked"
+	ChangeCriterionAdded     = "criterion-added"
+	ChangeCriterionRemoved   = "criterion-removed"
+)
+
+// Change is one difference between two versions of a SEP file
+type Change struct {
+	Kind string
+	From string // previous value: status, pilot; empty if none
+	To   string // new value: status, pilot or criterion text
+}
+
+// String describes the change for a timeline
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeCreated:
+		return fmt.Sprintf("created as %s", c.To)
+	case ChangeStatus:
+		return fmt.Sprintf("status %s → %s", c.From, c.To)
+	case ChangeAssigned:
+		switch {
+		case c.From == "":
+			return fmt.Sprintf("claimed by %s", c.To)
+		case c.To == "":
+			return fmt.Sprintf("unclaimed (was %s)", c.From)
+		default:
+			return fmt.Sprintf("reassigned from %s to %s", c.From, c.To)
+		}
+	case ChangeLease:
+		return fmt.Sprintf("lease extended to %s", c.To)
+	case ChangeCriterionChecked:
+		return fmt.Sprintf("checked: %s", c.To)
+	case ChangeCriterionUnchecked:
+		return fmt.Sprintf("unchecked: %s", c.To)
+	case ChangeCriterionAdded:
+		return fmt.Sprintf("criterion added: %s", c.To)
+	case ChangeCriterionRemoved:
+		return fmt.Sprintf("criterion removed: %s", c.To)
+	}
+	return c.Kind
+}
+
+// Diff lists the status, assignment, lease and Done When changes from prev
+// to next. A nil prev means next is the first version of the SEP.
+// Criteria are matched by their text.
+func Diff(prev, next *SEP) []Change {
+	if prev == nil {
+		changes := []Change{{Kind: ChangeCreated, To: next.Status}}
+		if next.Assigned != "" {
+			changes = append(changes, Change{Kind: ChangeAssigned, To: next.Assigned})
+		}
+		return changes
+	}
+
+	var changes []Change
+	if prev.Status != next.Status {
+		changes = append(changes, Change{Kind: ChangeStatus, From: prev.Status, To: next.Status})
+	}
+	if prev.Assigned != next.Assigned {
+		changes = append(changes, Change{Kind: ChangeAssigned, From: prev.Assigned, To: next.Assigned})
+	} else if next.Assigned != "" && next.LeaseUntil != "" && prev.LeaseUntil != next.LeaseUntil {
+		changes = append(changes, Change{Kind: ChangeLease, From: prev.LeaseUntil, To: next.LeaseUntil})
+	}
+
+	was := make(map[string]bool, len(prev.DoneWhen))
+	for i, criterion := range prev.DoneWhen {
+		was[criterion] = prev.DoneWhenStatus[i]
+	}
+	still := make(map[string]bool, len(next.DoneWhen))
+	for i, criterion := range next.DoneWhen {
+		still[criterion] = true
+		checked := next.DoneWhenStatus[i]
+		wasChecked, existed := was[criterion]
+		switch {
+		case !existed:
+			changes = append(changes, Change{Kind: ChangeCriterionAdded, To: criterion})
+			if checked {
+				changes = append(changes, Change{Kind: ChangeCriterionChecked, To: criterion})
+			}
+		case checked && !wasChecked:
+			changes = append(changes, Change{Kind: ChangeCriterionChecked, To: criterion})
+		case !checked && wasChecked:
+			changes = append(changes, Change{Kind: ChangeCriterionUnchecked, To: criterion})
+		}
+	}
+	for _, criterion := range prev.DoneWhen {
+		if !still[criterion] {
+			changes = append(changes, Change{Kind: ChangeCriterionRemoved, To: criterion})
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,101 @@
+package sep
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DateFormat is the layout of created, claimed_at and lease_until
+const DateFormat = "2006-01-02"
+
+// SetClaim records when the current claim started and until when it is
+// leased. Empty values remove the fields.
+func (s *SEP) SetClaim(claimedAt, leaseUntil string) error {
+	err := s.EditFrontmatter(func(fm *FrontmatterEditor) error {
+		fields := []struct{ key, value string }{{"claimed_at", claimedAt}, {"lease_until", leaseUntil}}
+		for _, f := range fields {
+			var err error
+			if f.value == "" {
+				err = fm.Delete(f.key)
+			} else {
+				// Written like created: a plain date rather than a quoted string
+				err = fm.Set(f.key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!timestamp", Value: f.value})
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.ClaimedAt = claimedAt
+	s.LeaseUntil = leaseUntil
+	return nil
+}
+
+// renewalMarker starts the subject of a lease renewal commit after the SEP
+// prefix
+const renewalMarker = "lease renewed by "
+
+// RenewalSubject returns the commit subject of a lease renewal, as recorded
+// by 'vibe sep renew' and by a pilot claiming their own SEP again
+func RenewalSubject(s *SEP, until string) string {
+	return fmt.Sprintf("%s: %s%s until %s", s.ID(), renewalMarker, s.Assigned, until)
+}
+
+// IsRenewalSubject reports whether a commit subject is a lease renewal.
+// Renewing only extends the claim, so it is not activity on the SEP.
+func IsRenewalSubject(subject string) bool {
+	_, rest, ok := strings.Cut(subject, ": ")
+	return ok && strings.HasPrefix(rest, renewalMarker)
+}
+
+// StaleReasons explains why the claim on s is stale at now: its lease
+// expired, or there was no activity for idleDays. lastActivity is the time
+// of the last SEP commit other than a lease renewal, zero if unknown, in
+// which case claimed_at is used. idleDays <= 0 disables the activity check.
+// Unassigned and inactive SEPs are never stale.
+func StaleReasons(s *SEP, lastActivity, now time.Time, idleDays int) []string {
+	if s.Assigned == "" || !IsActive(s.Status) {
+		return nil
+	}
+
+	today := now.Format(DateFormat)
+	var reasons []string
+
+	if s.LeaseUntil != "" && s.LeaseUntil < today {
+		reasons = append(reasons, fmt.Sprintf("lease expired %s", s.LeaseUntil))
+	}
+
+	if idleDays > 0 {
+		if lastActivity.IsZero() {
+			if claimed, err := time.Parse(DateFormat, s.ClaimedAt); err == nil {
+				lastActivity = claimed
+			}
+		}
+		if !lastActivity.IsZero() {
+			if idle := int(now.Sub(lastActivity).Hours() / 24); idle >= idleDays {
+				reasons = append(reasons, fmt.Sprintf("no %s commits for %d days", s.ID(), idle))
+			}
+		}
+	}
+
+	return reasons
+}
+
+// ClaimAge returns the number of days from claimed_at to now, or false if
+// the claim date is unknown
+func (s *SEP) ClaimAge(now time.Time) (int, bool) {
+	claimed, err := time.Parse(DateFormat, s.ClaimedAt)
+	if err != nil {
+		return 0, false
+	}
+	today, _ := time.Parse(DateFormat, now.Format(DateFormat))
+	return int(today.Sub(claimed).Hours() / 24), true
+}
@@ -0,0 +1,321 @@
+package sep
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Diagnostic is a problem found in a SEP file
+type Diagnostic struct {
+	File    string // Path to the SEP file
+	Line    int    // 1-based line, 0 if the problem concerns the whole file
+	Message string
+}
+
+// String formats the diagnostic as "file:line: message"
+func (d Diagnostic) String() string {
+	if d.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", d.File, d.Message)
+}
+
+// Error lets a Diagnostic be returned as an error
+func (d Diagnostic) Error() string {
+	return d.String()
+}
+
+var (
+	sepFileRe     = regexp.MustCompile(`^\d{4}-.*\.md$`)
+	titleNumberRe = regexp.MustCompile(`^SEP-(\d{4})\b`)
+	yamlLineRe    = regexp.MustCompile(`^line (\d+)`)
+	depNumberRe   = regexp.MustCompile(`^\d{4}$`)
+
+	// A line that is nothing but a "[...]" template placeholder, optionally
+	// as a list item or checkbox; markdown links are not placeholders
+	placeholderRe = regexp.MustCompile(`^\s*(?:[-*] (?:\[[ xX]\] )?)?\[[^\]]+\]\s*$`)
+)
+
+// requiredSections must be present in every SEP
+var requiredSections = []string{SectionWhatAndWhy, SectionDoneWhen}
+
+// Lint validates every SEP file in dir and returns the problems found,
+// ordered by file and line
+func Lint(dir string) ([]Diagnostic, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || !sepFileRe.MatchString(entry.Name()) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+
+	return LintFiles(files, knownNumbers(files)), nil
+}
+
+// LintFiles validates the given SEP files. known holds every SEP number
+// that depends_on may reference.
+func LintFiles(files []string, known map[string]bool) []Diagnostic {
+	var diags []Diagnostic
+
+	// Each number may only be used once
+	byNumber := make(map[string][]string)
+	for _, file := range files {
+		number := numberFromFilename(file)
+		byNumber[number] = append(byNumber[number], file)
+	}
+
+	for _, file := range files {
+		diags = append(diags, LintFile(file, known)...)
+
+		number := numberFromFilename(file)
+		if others := byNumber[number]; len(others) > 1 && others[0] != file {
+			diags = append(diags, Diagnostic{
+				File:    file,
+				Message: fmt.Sprintf("SEP number %s is already used by %s", number, others[0]),
+			})
+		}
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].File != diags[j].File {
+			return diags[i].File < diags[j].File
+		}
+		return diags[i].Line < diags[j].Line
+	})
+
+	return diags
+}
+
+// LintFile validates a single SEP file. known holds every SEP number that
+// depends_on may reference; pass nil to skip that check.
+func LintFile(file string, known map[string]bool) []Diagnostic {
+	var diags []Diagnostic
+	report := func(line int, format string, args ...interface{}) {
+		diags = append(diags, Diagnostic{File: file, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		report(0, "%v", err)
+		return diags
+	}
+
+	doc, err := ParseDocument(content)
+	if err != nil {
+		report(0, "%v", err)
+		return diags
+	}
+
+	number := numberFromFilename(file)
+	if doc.FrontmatterLine == 0 {
+		report(1, "missing YAML frontmatter")
+	} else {
+		diags = append(diags, lintFrontmatter(file, doc, known, number)...)
+	}
+
+	// The H1 heading must carry the number from the filename
+	if doc.TitleLine == 0 {
+		report(0, "missing \"# SEP-%s: <title>\" heading", number)
+	} else if m := titleNumberRe.FindStringSubmatch(doc.Title); m == nil {
+		report(doc.TitleLine, "heading should start with \"SEP-%s:\"", number)
+	} else if m[1] != number {
+		report(doc.TitleLine, "heading number SEP-%s does not match filename number %s", m[1], number)
+	}
+
+	// SEP-0000 documents the process itself rather than a feature
+	for _, heading := range requiredSections {
+		if number != "0000" && doc.Section(heading) == nil {
+			report(0, "missing \"## %s\" section", heading)
+		}
+	}
+
+	// Template placeholders left in
+	start := 1
+	if doc.FrontmatterLine > 0 {
+		start = doc.FrontmatterLine + strings.Count(doc.Frontmatter, "\n") + 2
+	}
+	inFence := false
+	for i, line := range doc.lines {
+		if i+1 < start {
+			continue
+		}
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			inFence = !inFence
+		}
+		if inFence {
+			continue
+		}
+		if placeholderRe.MatchString(line) || strings.Contains(line, "[Title]") {
+			report(i+1, "placeholder text left in: %s", strings.TrimSpace(line))
+		}
+	}
+
+	return diags
+}
+
+// lintFrontmatter validates the frontmatter fields of a SEP document
+func lintFrontmatter(file string, doc *Document, known map[string]bool, number string) []Diagnostic {
+	var diags []Diagnostic
+	report := func(line int, format string, args ...interface{}) {
+		diags = append(diags, Diagnostic{File: file, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// Frontmatter line numbers are relative to the YAML block
+	offset := doc.FrontmatterLine - 1
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(doc.Frontmatter), &root); err != nil {
+		diags = append(diags, yamlDiagnostic(file, doc, err))
+		return diags
+	}
+
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		report(doc.FrontmatterLine, "frontmatter must be a YAML mapping")
+		return diags
+	}
+
+	fields := make(map[string]*yaml.Node)
+	keyLines := make(map[string]int)
+	mapping := root.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i]
+		if _, dup := fields[key.Value]; dup {
+			report(offset+key.Line, "duplicate key %q", key.Value)
+		}
+		fields[key.Value] = mapping.Content[i+1]
+		keyLines[key.Value] = offset + key.Line
+	}
+
+	for _, key := range []string{"title", "status", "created"} {
+		if node, ok := fields[key]; !ok {
+			report(doc.FrontmatterLine-1, "missing required field %q", key)
+		} else if node.Kind != yaml.ScalarNode || strings.TrimSpace(node.Value) == "" {
+			report(keyLines[key], "field %q must be a non-empty string", key)
+		}
+	}
+
+	if node, ok := fields["title"]; ok && strings.Contains(node.Value, "[Title]") {
+		report(keyLines["title"], "placeholder text left in: title %q", node.Value)
+	}
+
+	if node, ok := fields["status"]; ok && node.Kind == yaml.ScalarNode && node.Value != "" {
+		if !IsValidStatus(node.Value) {
+			report(keyLines["status"], "invalid status %q (valid: %s)", node.Value, strings.Join(ValidStatuses, ", "))
+		}
+	}
+
+	if node, ok := fields["created"]; ok && node.Kind == yaml.ScalarNode && node.Value != "" {
+		if _, err := time.Parse("2006-01-02", node.Value); err != nil {
+			report(keyLines["created"], "created %q is not an ISO date (YYYY-MM-DD)", node.Value)
+		}
+	}
+
+	for _, key := range []string{"depends_on", "areas"} {
+		node, ok := fields[key]
+		if !ok || (node.Kind == yaml.ScalarNode && node.Tag == "!!null") {
+			continue
+		}
+		if node.Kind != yaml.SequenceNode {
+			report(keyLines[key], "field %q must be a list", key)
+			delete(fields, key)
+		}
+	}
+
+	if node, ok := fields["depends_on"]; ok && node.Kind == yaml.SequenceNode {
+		for _, item := range node.Content {
+			dep := item.Value
+			switch {
+			case item.Kind != yaml.ScalarNode || !depNumberRe.MatchString(dep):
+				report(offset+item.Line, "depends_on entry %q should be a 4-digit SEP number", dep)
+			case dep == number:
+				report(offset+item.Line, "SEP-%s depends on itself", dep)
+			case known != nil && !known[dep]:
+				report(offset+item.Line, "depends_on references unknown SEP-%s", dep)
+			}
+		}
+	}
+
+	if node, ok := fields["assigned"]; ok && node.Kind != yaml.ScalarNode {
+		report(keyLines["assigned"], "field \"assigned\" must be a string")
+	}
+
+	return diags
+}
+
+// yamlDiagnostic converts a frontmatter YAML error into a diagnostic
+// pointing at the offending line of the file
+func yamlDiagnostic(file string, doc *Document, err error) Diagnostic {
+	line := doc.FrontmatterLine
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	if m := yamlLineRe.FindStringSubmatch(msg); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		line += n - 1
+		msg = strings.TrimPrefix(strings.TrimPrefix(msg, m[0]), ": ")
+	}
+	return Diagnostic{File: file, Line: line, Message: "invalid frontmatter: " + msg}
+}
+
+// knownNumbers collects the SEP numbers of the given files
+func knownNumbers(files []string) map[string]bool {
+	known := make(map[string]bool)
+	for _, file := range files {
+		known[numberFromFilename(file)] = true
+	}
+	return known
+}
+
+// KnownNumbers returns the numbers of the SEPs in dir, for LintFiles. SEPs
+// that fail to parse count too: they exist, and linting them reports why.
+func KnownNumbers(dir string) (map[string]bool, error) {
+	seps, parseErrs, err := ListWithErrors(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	for _, s := range seps {
+		known[s.Number] = true
+	}
+	for _, err := range parseErrs {
+		var diag Diagnostic
+		var pathErr *fs.PathError
+		switch {
+		case errors.As(err, &diag):
+			known[numberFromFilename(diag.File)] = true
+		case errors.As(err, &pathErr):
+			known[numberFromFilename(pathErr.Path)] = true
+		}
+	}
+	return known, nil
+}
+
+// numberFromFilename extracts the 4-digit number from a SEP filename
+func numberFromFilename(file string) string {
+	return strings.SplitN(filepath.Base(file), "-", 2)[0]
+}
+
+// IsValidStatus reports whether status is one of ValidStatuses
+func IsValidStatus(status string) bool {
+	for _, s := range ValidStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
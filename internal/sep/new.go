@@ -0,0 +1,114 @@
+package sep
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Draft is the content of a SEP about to be created
+type Draft struct {
+	Number     string
+	Title      string
+	Created    string   // YYYY-MM-DD
+	WhatAndWhy string   // replaces the template's What & Why unless empty
+	Criteria   []string // replace the template's Done When items unless empty
+	Areas      []string
+	DependsOn  []string
+	Assigned   string
+}
+
+// Validate checks the draft can be written as a SEP
+func (d Draft) Validate() error {
+	if strings.TrimSpace(d.Title) == "" {
+		return fmt.Errorf("a SEP needs a title")
+	}
+	for _, c := range d.Criteria {
+		if err := ValidateCriterion(c); err != nil {
+			return err
+		}
+	}
+	for _, area := range d.Areas {
+		if normalizeArea(area) == "" {
+			return fmt.Errorf("empty area")
+		}
+	}
+	for _, dep := range d.DependsOn {
+		if !depNumberRe.MatchString(dep) {
+			return fmt.Errorf("depends_on entry %q should be a 4-digit SEP number", dep)
+		}
+		if dep == d.Number {
+			return fmt.Errorf("SEP-%s cannot depend on itself", dep)
+		}
+	}
+	return nil
+}
+
+// Render fills a SEP template with the draft. The template's placeholders
+// are replaced, frontmatter fields are set through FrontmatterEditor, and
+// the What & Why and Done When sections are replaced when the draft has
+// content for them.
+func (d Draft) Render(template []byte) ([]byte, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	fm, err := NewFrontmatterEditor(template)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []struct {
+		key   string
+		value interface{}
+		set   bool
+	}{
+		{"title", d.Title, true},
+		// A plain date, as in the template, rather than a quoted string
+		{"created", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!timestamp", Value: d.Created}, true},
+		{"depends_on", d.DependsOn, len(d.DependsOn) > 0},
+		{"areas", d.Areas, len(d.Areas) > 0},
+		{"assigned", d.Assigned, d.Assigned != ""},
+	}
+	for _, f := range fields {
+		if !f.set {
+			continue
+		}
+		if err := fm.Set(f.key, f.value); err != nil {
+			return nil, err
+		}
+	}
+
+	// The body still holds the template placeholders; the frontmatter
+	// values above are set as written
+	fm.body = strings.NewReplacer(
+		"SEP-XXXX", "SEP-"+d.Number,
+		"XXXX", d.Number,
+		"[Title]", d.Title,
+		"YYYY-MM-DD", d.Created,
+	).Replace(fm.body)
+
+	doc, err := ParseDocument(fm.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	if why := strings.TrimSpace(d.WhatAndWhy); why != "" {
+		if err := doc.SetSection(SectionWhatAndWhy, why); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(d.Criteria) > 0 {
+		var lines []string
+		for _, c := range d.Criteria {
+			lines = append(lines, "- [ ] "+strings.TrimSpace(c))
+		}
+		if err := doc.SetSection(SectionDoneWhen, strings.Join(lines, "\n")); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc.Bytes(), nil
+}
@@ -0,0 +1,207 @@
+package sep
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sort keys for Query.Sort
+const (
+	SortNumber   = "number"
+	SortCreated  = "created"
+	SortTitle    = "title"
+	SortProgress = "progress"
+)
+
+// SortKeys lists the valid Query.Sort values
+var SortKeys = []string{SortNumber, SortCreated, SortTitle, SortProgress}
+
+// Query selects and orders SEPs. Zero-valued fields do not filter; a SEP
+// must match every filter that is set.
+type Query struct {
+	Statuses      []string // any of these statuses, matched case-insensitively by Validate
+	Assignee      string   // assigned pilot, compared with SamePilot
+	Unassigned    bool     // no pilot assigned
+	Area          string   // path or pattern overlapping one of the SEP's areas
+	DependsOn     string   // SEP number listed in depends_on
+	CreatedAfter  string   // YYYY-MM-DD, inclusive
+	CreatedBefore string   // YYYY-MM-DD, inclusive
+	Text          string   // case-insensitive substring of the title or any section
+	HasConflicts  bool     // areas overlap those of another active SEP
+
+	Sort    string // one of SortKeys; SortNumber if empty
+	Reverse bool
+}
+
+// Validate checks the filter values and normalizes statuses and numbers
+func (q *Query) Validate() error {
+	for i, status := range q.Statuses {
+		canonical := ""
+		for _, valid := range ValidStatuses {
+			if strings.EqualFold(valid, strings.TrimSpace(status)) {
+				canonical = valid
+			}
+		}
+		if canonical == "" {
+			return fmt.Errorf("invalid status %q (valid: %s)", status, strings.Join(ValidStatuses, ", "))
+		}
+		q.Statuses[i] = canonical
+	}
+
+	if q.Assignee != "" && q.Unassigned {
+		return fmt.Errorf("an assignee and unassigned cannot both be selected")
+	}
+
+	if q.DependsOn != "" {
+		q.DependsOn = NormalizeNumber(q.DependsOn)
+	}
+
+	for _, date := range []string{q.CreatedAfter, q.CreatedBefore} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(DateFormat, date); err != nil {
+			return fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", date)
+		}
+	}
+
+	switch q.Sort {
+	case "", SortNumber, SortCreated, SortTitle, SortProgress:
+	default:
+		return fmt.Errorf("invalid sort key %q (valid: %s)", q.Sort, strings.Join(SortKeys, ", "))
+	}
+
+	return nil
+}
+
+// Run returns the SEPs matching the query in its sort order. Conflicts are
+// detected among all of seps, not just the matching ones.
+func (q Query) Run(seps []*SEP) ([]*SEP, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	var conflicting map[*SEP]bool
+	if q.HasConflicts {
+		conflicting = make(map[*SEP]bool)
+		for _, c := range FindConflicts(seps) {
+			conflicting[c.SEP1] = true
+			conflicting[c.SEP2] = true
+		}
+	}
+
+	var matched []*SEP
+	for _, s := range seps {
+		if q.matches(s) && (conflicting == nil || conflicting[s]) {
+			matched = append(matched, s)
+		}
+	}
+
+	q.sort(matched)
+	return matched, nil
+}
+
+// matches applies every filter but HasConflicts
+func (q Query) matches(s *SEP) bool {
+	if len(q.Statuses) > 0 && !contains(q.Statuses, s.Status) {
+		return false
+	}
+	if q.Assignee != "" && !SamePilot(s.Assigned, q.Assignee) {
+		return false
+	}
+	if q.Unassigned && strings.TrimSpace(s.Assigned) != "" {
+		return false
+	}
+	if q.Area != "" && !q.matchesArea(s) {
+		return false
+	}
+	if q.DependsOn != "" && !q.matchesDependency(s) {
+		return false
+	}
+	if q.CreatedAfter != "" || q.CreatedBefore != "" {
+		// Only well-formed dates compare correctly as strings
+		if _, err := time.Parse(DateFormat, s.Created); err != nil {
+			return false
+		}
+		if q.CreatedAfter != "" && s.Created < q.CreatedAfter {
+			return false
+		}
+		if q.CreatedBefore != "" && s.Created > q.CreatedBefore {
+			return false
+		}
+	}
+	if q.Text != "" && !q.matchesText(s) {
+		return false
+	}
+	return true
+}
+
+func (q Query) matchesArea(s *SEP) bool {
+	for _, area := range s.Areas {
+		if AreasOverlap(area, q.Area) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q Query) matchesDependency(s *SEP) bool {
+	for _, dep := range s.DependsOn {
+		if NormalizeNumber(dep) == q.DependsOn {
+			return true
+		}
+	}
+	return false
+}
+
+func (q Query) matchesText(s *SEP) bool {
+	text := strings.ToLower(q.Text)
+	if strings.Contains(strings.ToLower(s.Title), text) {
+		return true
+	}
+	for _, section := range s.Sections {
+		if strings.Contains(strings.ToLower(section.Body), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// sort orders seps by the query's sort key, breaking ties by number
+func (q Query) sort(seps []*SEP) {
+	less := func(a, b *SEP) bool {
+		switch q.Sort {
+		case SortCreated:
+			if a.Created != b.Created {
+				return a.Created < b.Created
+			}
+		case SortTitle:
+			if ta, tb := strings.ToLower(a.Title), strings.ToLower(b.Title); ta != tb {
+				return ta < tb
+			}
+		case SortProgress:
+			if pa, pb := a.ProgressRatio(), b.ProgressRatio(); pa != pb {
+				return pa < pb
+			}
+		}
+		return a.Number < b.Number
+	}
+
+	sort.SliceStable(seps, func(i, j int) bool {
+		if q.Reverse {
+			return less(seps[j], seps[i])
+		}
+		return less(seps[i], seps[j])
+	})
+}
+
+// NormalizeNumber turns "1", "0001" or "SEP-0001" into "0001"
+func NormalizeNumber(number string) string {
+	number = strings.TrimPrefix(strings.TrimSpace(number), "SEP-")
+	if len(number) < 4 {
+		number = fmt.Sprintf("%04s", number)
+	}
+	return number
+}
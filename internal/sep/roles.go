@@ -0,0 +1,93 @@
+package sep
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role is a participant in the SEP workflow and the statuses it moves SEPs
+// into. A role with members restricts those moves to them.
+type Role struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Statuses    []string `yaml:"statuses,omitempty"` // Statuses this role moves SEPs into
+	Members     []string `yaml:"members,omitempty"`  // Who holds the role, e.g. "@alice"; empty means anyone
+}
+
+// Set by SetRoles
+var (
+	// Roles are the roles of the current workflow
+	Roles []Role
+
+	// actor is who moves SEPs, matched against role members
+	actor string
+)
+
+// DefaultRoles returns the built-in roles. They have no members, so anyone
+// may move SEPs into any status.
+func DefaultRoles() []Role {
+	return []Role{
+		{Name: "Author", Description: "Draft SEPs - anyone on the team", Statuses: []string{StatusDraft}},
+		{Name: "Editor", Description: "Review, give feedback, approve SEPs", Statuses: []string{StatusAccepted, StatusCancelled}},
+		{Name: "Pilot", Description: "Implement approved SEPs with AI agents", Statuses: []string{StatusInProgress, StatusInReview, StatusDone}},
+	}
+}
+
+// SetRoles validates roles against the current workflow and makes them the
+// ones CheckTransition enforces for moves made by who
+func SetRoles(roles []Role, who string) error {
+	for _, r := range roles {
+		if strings.TrimSpace(r.Name) == "" {
+			return fmt.Errorf("roles: a role needs a name")
+		}
+		for _, status := range r.Statuses {
+			if !IsValidStatus(status) {
+				return fmt.Errorf("roles: %s: unknown status %s", r.Name, status)
+			}
+		}
+	}
+	Roles = roles
+	actor = who
+	return nil
+}
+
+// RolesRestricted reports whether any role limits who may move SEPs
+func RolesRestricted(roles []Role) bool {
+	for _, r := range roles {
+		if len(r.Members) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckRole verifies that who may move SEPs into status: it must be a
+// member of a role listing status, unless one of those roles is open to
+// anyone or no role lists it
+func CheckRole(who, status string) error {
+	var names, members []string
+	for _, r := range Roles {
+		if !contains(r.Statuses, status) {
+			continue
+		}
+		if len(r.Members) == 0 {
+			return nil
+		}
+		for _, m := range r.Members {
+			if SamePilot(m, who) {
+				return nil
+			}
+		}
+		names = append(names, r.Name)
+		members = append(members, r.Members...)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	if who == "" {
+		who = "unknown user"
+	}
+	return fmt.Errorf("only %s (%s) may move SEPs to %s, not %s",
+		strings.Join(names, " or "), strings.Join(members, ", "), status, who)
+}
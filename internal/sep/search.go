@@ -0,0 +1,291 @@
+package sep
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// A search query is a list of terms that must all occur in a SEP. Terms
+// are case-insensitive substrings; a quoted "exact phrase" is one term.
+// Prefixing a term with a field limits it:
+//
+//	title:auth            the title
+//	what:, done:          the What & Why and Done When sections
+//	plan:, notes:         the Plan and Implementation Notes sections
+//	status:DONE           only SEPs in this status (a filter, not scored)
+//	assigned:@alice       only SEPs assigned to this pilot (a filter)
+//
+// Values may be quoted too: title:"rate limit".
+
+// searchSections maps field names to the section they search
+var searchSections = map[string]string{
+	"what":  SectionWhatAndWhy,
+	"why":   SectionWhatAndWhy,
+	"done":  SectionDoneWhen,
+	"plan":  SectionPlan,
+	"notes": SectionImplementationNotes,
+}
+
+// searchWeights ranks a hit by where it occurs; other sections weigh 1
+var searchWeights = map[string]int{
+	"Title":           5,
+	SectionWhatAndWhy: 3,
+	SectionDoneWhen:   2,
+}
+
+// maxSnippets bounds the snippets per result
+const maxSnippets = 3
+
+// snippetWidth is the length in bytes a snippet line is cut down to
+const snippetWidth = 100
+
+// SearchTerm is one term of a search query
+type SearchTerm struct {
+	Text  string // lower-cased
+	Field string // "title" or a section heading; "" for anywhere
+}
+
+// SearchQuery is a parsed search query
+type SearchQuery struct {
+	Terms    []SearchTerm
+	Statuses []string // status: filters, any of them
+	Assignee string   // assigned: filter
+}
+
+// SearchResult is a SEP matching a query
+type SearchResult struct {
+	SEP      *SEP
+	Score    int
+	Snippets []Snippet
+}
+
+// Snippet is a line of a SEP field containing a match
+type Snippet struct {
+	Field      string   // "Title" or the section heading
+	Text       string   // the line, shortened around the first match
+	Highlights [][2]int // byte ranges of Text matching a term
+}
+
+var searchTokenRe = regexp.MustCompile(`(?:(\w+):)?(?:"([^"]*)"?|(\S+))`)
+
+// ParseSearchQuery parses a search query. Field prefixes that are not
+// known are searched as plain text.
+func ParseSearchQuery(query string) SearchQuery {
+	var q SearchQuery
+	for _, m := range searchTokenRe.FindAllStringSubmatch(query, -1) {
+		field, text := strings.ToLower(m[1]), m[2]+m[3]
+		if text == "" {
+			continue
+		}
+
+		switch {
+		case field == "":
+		case field == "title":
+			field = "Title"
+		case field == "status":
+			q.Statuses = append(q.Statuses, text)
+			continue
+		case field == "assigned" || field == "assignee":
+			q.Assignee = text
+			continue
+		case searchSections[field] != "":
+			field = searchSections[field]
+		default:
+			// Not a field, e.g. "http://host"
+			field, text = "", m[0]
+		}
+
+		q.Terms = append(q.Terms, SearchTerm{Text: strings.ToLower(text), Field: field})
+	}
+	return q
+}
+
+// Search returns the SEPs matching every term and filter of the query,
+// best matches first
+func Search(seps []*SEP, q SearchQuery) []SearchResult {
+	var results []SearchResult
+	for _, s := range seps {
+		if len(q.Statuses) > 0 && !matchesStatus(s.Status, q.Statuses) {
+			continue
+		}
+		if q.Assignee != "" && !SamePilot(s.Assigned, q.Assignee) {
+			continue
+		}
+		if result, ok := searchSEP(s, q.Terms); ok {
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].SEP.Number < results[j].SEP.Number
+	})
+	return results
+}
+
+func matchesStatus(status string, statuses []string) bool {
+	for _, s := range statuses {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchField is a searchable part of a SEP
+type searchField struct {
+	name  string
+	text  string
+	lower string
+}
+
+// searchFields returns the title and every section of s
+func searchFields(s *SEP) []searchField {
+	fields := []searchField{{name: "Title", text: s.Title}}
+	for _, section := range s.Sections {
+		fields = append(fields, searchField{name: section.Heading, text: section.Body})
+	}
+	for i := range fields {
+		fields[i].lower = strings.ToLower(fields[i].text)
+	}
+	return fields
+}
+
+// searchSEP scores s against terms, failing if any term is missing
+func searchSEP(s *SEP, terms []SearchTerm) (SearchResult, bool) {
+	result := SearchResult{SEP: s}
+	fields := searchFields(s)
+	hit := make([]bool, len(fields))
+
+	for _, term := range terms {
+		found := false
+		for i, f := range fields {
+			if term.Field != "" && !strings.EqualFold(term.Field, f.name) {
+				continue
+			}
+			count := strings.Count(f.lower, term.Text)
+			if count == 0 {
+				continue
+			}
+			found = true
+			hit[i] = true
+			weight := searchWeights[f.name]
+			if weight == 0 {
+				weight = 1
+			}
+			result.Score += count * weight
+		}
+		if !found {
+			return SearchResult{}, false
+		}
+	}
+
+	for i, f := range fields {
+		if hit[i] && len(result.Snippets) < maxSnippets {
+			result.Snippets = append(result.Snippets, snippet(f, terms))
+		}
+	}
+	return result, true
+}
+
+// snippet cuts the first line of f containing a term down to
+// snippetWidth around the match and marks every term in it
+func snippet(f searchField, terms []SearchTerm) Snippet {
+	lines := strings.Split(f.text, "\n")
+	lowerLines := strings.Split(f.lower, "\n")
+
+	line, lower, at := "", "", -1
+	for i := range lines {
+		for _, term := range terms {
+			if term.Field != "" && !strings.EqualFold(term.Field, f.name) {
+				continue
+			}
+			if pos := strings.Index(lowerLines[i], term.Text); pos >= 0 && (at < 0 || pos < at) {
+				line, lower, at = lines[i], lowerLines[i], pos
+			}
+		}
+		if at >= 0 {
+			break
+		}
+	}
+
+	// Lower-casing may change byte lengths; then the line is not cut and
+	// nothing is highlighted rather than highlighting the wrong bytes
+	if len(line) != len(lower) {
+		return Snippet{Field: f.name, Text: strings.TrimSpace(line)}
+	}
+
+	start, end := 0, len(line)
+	if end > snippetWidth {
+		start = at - snippetWidth/3
+		if start < 0 {
+			start = 0
+		}
+		end = start + snippetWidth
+		if end > len(line) {
+			end = len(line)
+			start = end - snippetWidth
+		}
+		for start > 0 && !utf8.RuneStart(line[start]) {
+			start--
+		}
+		for end < len(line) && !utf8.RuneStart(line[end]) {
+			end++
+		}
+	}
+
+	// Trim surrounding whitespace without losing track of the offsets
+	for start < end && (line[start] == ' ' || line[start] == '\t') {
+		start++
+	}
+	for end > start && (line[end-1] == ' ' || line[end-1] == '\t') {
+		end--
+	}
+
+	prefix, suffix := "", ""
+	if start > 0 && strings.TrimSpace(line[:start]) != "" {
+		prefix = "…"
+	}
+	if end < len(line) && strings.TrimSpace(line[end:]) != "" {
+		suffix = "…"
+	}
+
+	sn := Snippet{Field: f.name, Text: prefix + line[start:end] + suffix}
+	window := lower[start:end]
+	for _, term := range terms {
+		if term.Field != "" && !strings.EqualFold(term.Field, f.name) {
+			continue
+		}
+		for from := 0; ; {
+			pos := strings.Index(window[from:], term.Text)
+			if pos < 0 {
+				break
+			}
+			begin := len(prefix) + from + pos
+			sn.Highlights = append(sn.Highlights, [2]int{begin, begin + len(term.Text)})
+			from += pos + len(term.Text)
+		}
+	}
+	sn.Highlights = mergeRanges(sn.Highlights)
+	return sn
+}
+
+// mergeRanges sorts byte ranges and merges overlapping ones
+func mergeRanges(ranges [][2]int) [][2]int {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	var merged [][2]int
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1] {
+			if r[1] > merged[n-1][1] {
+				merged[n-1][1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
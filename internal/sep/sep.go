@@ -1,11 +1,11 @@
 package sep
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -13,22 +13,15 @@ import (
 
 // Status constants for SEP lifecycle
 const (
-	StatusDraft     = "DRAFT"
-	StatusAccepted  = "ACCEPTED"
-	StatusBlocked   = "BLOCKED"
-	StatusCancelled = "CANCELLED"
-	StatusDone      = "DONE"
+	StatusDraft      = "DRAFT"
+	StatusAccepted   = "ACCEPTED"
+	StatusInProgress = "IN_PROGRESS"
+	StatusInReview   = "IN_REVIEW"
+	StatusBlocked    = "BLOCKED"
+	StatusCancelled  = "CANCELLED"
+	StatusDone       = "DONE"
 )
 
-// ValidStatuses lists all valid SEP statuses
-var ValidStatuses = []string{
-	StatusDraft,
-	StatusAccepted,
-	StatusBlocked,
-	StatusCancelled,
-	StatusDone,
-}
-
 // Frontmatter represents the YAML frontmatter of a SEP
 type Frontmatter struct {
 	Title     string   `yaml:"title"`
@@ -37,32 +30,53 @@ type Frontmatter struct {
 	DependsOn []string `yaml:"depends_on"`
 	Areas     []string `yaml:"areas,omitempty"`
 	Assigned  string   `yaml:"assigned,omitempty"`
+
+	ClaimedAt  string `yaml:"claimed_at,omitempty"`
+	LeaseUntil string `yaml:"lease_until,omitempty"`
+
+	ForcedTransitions []string `yaml:"forced_transitions,omitempty"`
 }
 
 // SEP represents a Software Enhancement Proposal
 type SEP struct {
-	Number         string   // e.g., "0001"
-	Title          string   // e.g., "User Authentication"
-	Status         string   // DRAFT, ACCEPTED, BLOCKED, CANCELLED, DONE
-	Created        string   // YYYY-MM-DD
-	DependsOn      []string // e.g., ["0001", "0002"]
-	Areas          []string // e.g., ["auth/*", "api/routes/login.go"]
-	Assigned       string   // e.g., "@alice" - pilot assigned to implement
-	WhatAndWhy     string   // Content of What & Why section
-	DoneWhen       []string // Acceptance criteria
-	DoneWhenStatus []bool   // Checked status of each criterion
-	FilePath       string   // Full path to file
+	Number         string     // e.g., "0001"
+	Title          string     // e.g., "User Authentication"
+	Status         string     // DRAFT, ACCEPTED, IN_PROGRESS, IN_REVIEW, BLOCKED, CANCELLED, DONE
+	Created        string     // YYYY-MM-DD
+	DependsOn      []string   // e.g., ["0001", "0002"]
+	Areas          []string   // e.g., ["auth/*", "api/routes/login.go"]
+	Assigned       string     // e.g., "@alice" - pilot assigned to implement
+	WhatAndWhy     string     // Content of What & Why section
+	DoneWhen       []string   // Acceptance criteria, without annotation comments
+	DoneWhenStatus []bool     // Checked status of each criterion
+	Sections       []*Section // All "## " sections in file order
+	FilePath       string     // Full path to file
+
+	ClaimedAt  string // YYYY-MM-DD the current claim started
+	LeaseUntil string // YYYY-MM-DD after which the claim is stale
+
+	ForcedTransitions []string // Status changes that bypassed the state machine
 }
 
 // Parse reads a SEP file and extracts its content
 func Parse(filePath string) (*SEP, error) {
-	file, err := os.Open(filePath)
+	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
+	return ParseContent(filePath, content)
+}
 
-	sep := &SEP{FilePath: filePath}
+// ParseContent extracts a SEP from content, e.g. a copy of filePath read
+// from another git revision. filePath provides the number and is used in
+// diagnostics.
+func ParseContent(filePath string, content []byte) (*SEP, error) {
+	doc, err := ParseDocument(content)
+	if err != nil {
+		return nil, Diagnostic{File: filePath, Message: err.Error()}
+	}
+
+	sep := &SEP{FilePath: filePath, Sections: doc.Sections}
 
 	// Extract number from filename
 	base := filepath.Base(filePath)
@@ -71,71 +85,36 @@ func Parse(filePath string) (*SEP, error) {
 		sep.Number = numMatch[1]
 	}
 
-	scanner := bufio.NewScanner(file)
-	var inFrontmatter bool
-	var frontmatterLines strings.Builder
-	var currentSection string
-	var whatAndWhy strings.Builder
-
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		// Handle YAML frontmatter
-		if line == "---" {
-			if !inFrontmatter {
-				inFrontmatter = true
-				continue
-			} else {
-				// End of frontmatter, parse it
-				inFrontmatter = false
-				var fm Frontmatter
-				if err := yaml.Unmarshal([]byte(frontmatterLines.String()), &fm); err == nil {
-					sep.Title = fm.Title
-					sep.Status = fm.Status
-					sep.Created = fm.Created
-					sep.DependsOn = fm.DependsOn
-					sep.Areas = fm.Areas
-					sep.Assigned = fm.Assigned
-				}
-				continue
-			}
-		}
-
-		if inFrontmatter {
-			frontmatterLines.WriteString(line)
-			frontmatterLines.WriteString("\n")
-			continue
-		}
-
-		// Track sections
-		if after, found := strings.CutPrefix(line, "## "); found {
-			currentSection = after
-			continue
-		}
-
-		// Parse section content
-		switch currentSection {
-		case "What & Why":
+	var fm Frontmatter
+	if err := yaml.Unmarshal([]byte(doc.Frontmatter), &fm); err != nil {
+		return nil, yamlDiagnostic(filePath, doc, err)
+	}
+	sep.Title = fm.Title
+	sep.Status = fm.Status
+	sep.Created = fm.Created
+	sep.DependsOn = fm.DependsOn
+	sep.Areas = fm.Areas
+	sep.Assigned = fm.Assigned
+	sep.ClaimedAt = fm.ClaimedAt
+	sep.LeaseUntil = fm.LeaseUntil
+	sep.ForcedTransitions = fm.ForcedTransitions
+
+	if section := doc.Section(SectionWhatAndWhy); section != nil {
+		var whatAndWhy strings.Builder
+		for _, line := range strings.Split(section.Body, "\n") {
 			if line != "" && !strings.HasPrefix(line, "[") {
 				whatAndWhy.WriteString(line)
 				whatAndWhy.WriteString("\n")
 			}
-		case "Done When":
-			if strings.HasPrefix(line, "- [") {
-				checked := strings.HasPrefix(line, "- [x]") || strings.HasPrefix(line, "- [X]")
-				criterion := strings.TrimSpace(line[5:])
-				if criterion != "" && !strings.HasPrefix(criterion, "[") {
-					sep.DoneWhen = append(sep.DoneWhen, criterion)
-					sep.DoneWhenStatus = append(sep.DoneWhenStatus, checked)
-				}
-			}
 		}
+		sep.WhatAndWhy = strings.TrimSpace(whatAndWhy.String())
 	}
 
-	sep.WhatAndWhy = strings.TrimSpace(whatAndWhy.String())
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	if section := doc.Section(SectionDoneWhen); section != nil {
+		for _, c := range ParseCriteria(section.Body) {
+			sep.DoneWhen = append(sep.DoneWhen, c.Text)
+			sep.DoneWhenStatus = append(sep.DoneWhenStatus, c.Checked)
+		}
 	}
 
 	return sep, nil
@@ -143,27 +122,35 @@ func Parse(filePath string) (*SEP, error) {
 
 // List finds and parses all SEPs in the given directory
 func List(dir string) ([]*SEP, error) {
+	seps, _, err := ListWithErrors(dir)
+	return seps, err
+}
+
+// ListWithErrors finds and parses all SEPs in the given directory, also
+// returning the errors for files that could not be parsed
+func ListWithErrors(dir string) ([]*SEP, []error, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	re := regexp.MustCompile(`^\d{4}-.*\.md$`)
 	var seps []*SEP
+	var parseErrs []error
 
 	for _, entry := range entries {
-		if entry.IsDir() || !re.MatchString(entry.Name()) {
+		if entry.IsDir() || !sepFileRe.MatchString(entry.Name()) {
 			continue
 		}
 
 		sep, err := Parse(filepath.Join(dir, entry.Name()))
 		if err != nil {
-			continue // Skip files that can't be parsed
+			parseErrs = append(parseErrs, err)
+			continue
 		}
 		seps = append(seps, sep)
 	}
 
-	return seps, nil
+	return seps, parseErrs, nil
 }
 
 // GroupByStatus groups SEPs by their status
@@ -175,6 +162,25 @@ func GroupByStatus(seps []*SEP) map[string][]*SEP {
 	return groups
 }
 
+// StatusOrder returns the statuses of groups in DisplayOrder, followed by
+// any statuses not in it, such as ones unknown to the workflow, sorted by
+// name, so no group is left out
+func StatusOrder(groups map[string][]*SEP) []string {
+	var order, rest []string
+	for _, status := range DisplayOrder {
+		if len(groups[status]) > 0 {
+			order = append(order, status)
+		}
+	}
+	for status, seps := range groups {
+		if len(seps) > 0 && !contains(DisplayOrder, status) {
+			rest = append(rest, status)
+		}
+	}
+	sort.Strings(rest)
+	return append(order, rest...)
+}
+
 // FindByNumber finds a SEP by its number in the given directory
 func FindByNumber(dir, number string) (*SEP, error) {
 	// Pad number if needed
@@ -220,6 +226,31 @@ func (s *SEP) ID() string {
 	return fmt.Sprintf("SEP-%s", s.Number)
 }
 
+// CriteriaDone returns how many Done When criteria are checked
+func (s *SEP) CriteriaDone() int {
+	done := 0
+	for _, checked := range s.DoneWhenStatus {
+		if checked {
+			done++
+		}
+	}
+	return done
+}
+
+// Progress formats the checked criteria, e.g. "2/5"
+func (s *SEP) Progress() string {
+	return fmt.Sprintf("%d/%d", s.CriteriaDone(), len(s.DoneWhen))
+}
+
+// ProgressRatio returns the share of checked Done When criteria, from 0 to
+// 1. SEPs without criteria count as 0.
+func (s *SEP) ProgressRatio() float64 {
+	if len(s.DoneWhen) == 0 {
+		return 0
+	}
+	return float64(s.CriteriaDone()) / float64(len(s.DoneWhen))
+}
+
 // Conflict represents an overlap between two SEPs
 type Conflict struct {
 	SEP1         *SEP
@@ -229,6 +260,14 @@ type Conflict struct {
 
 // FindConflicts detects SEPs with overlapping areas
 func FindConflicts(seps []*SEP) []Conflict {
+	return findConflicts(seps, func(a, b *SEP) []string {
+		return findOverlappingAreas(a.Areas, b.Areas)
+	})
+}
+
+// findConflicts pairs up active SEPs with areas and records a conflict
+// whenever overlap returns something
+func findConflicts(seps []*SEP, overlap func(a, b *SEP) []string) []Conflict {
 	var conflicts []Conflict
 
 	for i := 0; i < len(seps); i++ {
@@ -238,15 +277,12 @@ func FindConflicts(seps []*SEP) []Conflict {
 				continue
 			}
 
-			// Skip if either is DONE or CANCELLED
-			if seps[i].Status == StatusDone || seps[i].Status == StatusCancelled {
-				continue
-			}
-			if seps[j].Status == StatusDone || seps[j].Status == StatusCancelled {
+			// Skip if either is inactive (DONE or CANCELLED by default)
+			if !IsActive(seps[i].Status) || !IsActive(seps[j].Status) {
 				continue
 			}
 
-			overlaps := findOverlappingAreas(seps[i].Areas, seps[j].Areas)
+			overlaps := overlap(seps[i], seps[j])
 			if len(overlaps) > 0 {
 				conflicts = append(conflicts, Conflict{
 					SEP1:         seps[i],
@@ -260,13 +296,19 @@ func FindConflicts(seps []*SEP) []Conflict {
 	return conflicts
 }
 
+// InFlight reports whether both SEPs of the conflict are being implemented
+// or reviewed, i.e. the overlap is likely to produce merge conflicts
+func (c Conflict) InFlight() bool {
+	return IsInFlight(c.SEP1.Status) && IsInFlight(c.SEP2.Status)
+}
+
 // findOverlappingAreas checks if two area lists have overlaps
 func findOverlappingAreas(areas1, areas2 []string) []string {
 	var overlaps []string
 
 	for _, a1 := range areas1 {
 		for _, a2 := range areas2 {
-			if areasOverlap(a1, a2) {
+			if AreasOverlap(a1, a2) {
 				// Add the more specific one, or both if equal
 				if len(a1) >= len(a2) {
 					overlaps = append(overlaps, a1)
@@ -280,58 +322,32 @@ func findOverlappingAreas(areas1, areas2 []string) []string {
 	return overlaps
 }
 
-// areasOverlap checks if two area patterns overlap
-func areasOverlap(a1, a2 string) bool {
-	// Exact match
-	if a1 == a2 {
-		return true
-	}
-
-	// Check if one is a prefix of the other (directory containment)
-	// e.g., "internal/user/*" overlaps with "internal/user/model.go"
-	a1Base := strings.TrimSuffix(a1, "/*")
-	a2Base := strings.TrimSuffix(a2, "/*")
-
-	if strings.HasPrefix(a1, a2Base) || strings.HasPrefix(a2, a1Base) {
-		return true
-	}
-
-	return false
-}
-
-// UpdateStatus updates the status field in a SEP file
-func (s *SEP) UpdateStatus(newStatus string) error {
+// EditFrontmatter applies fn to the frontmatter of the SEP file and writes
+// the result back, leaving every key fn does not touch exactly as it was
+func (s *SEP) EditFrontmatter(fn func(fm *FrontmatterEditor) error) error {
 	content, err := os.ReadFile(s.FilePath)
 	if err != nil {
 		return err
 	}
 
-	// Extract frontmatter
-	parts := strings.SplitN(string(content), "---", 3)
-	if len(parts) < 3 {
-		return fmt.Errorf("invalid frontmatter format")
+	fm, err := NewFrontmatterEditor(content)
+	if err != nil {
+		return err
 	}
 
-	// Parse frontmatter
-	var fm Frontmatter
-	if err := yaml.Unmarshal([]byte(parts[1]), &fm); err != nil {
-		return fmt.Errorf("failed to parse frontmatter: %w", err)
+	if err := fn(fm); err != nil {
+		return err
 	}
 
-	// Update status
-	fm.Status = newStatus
+	return os.WriteFile(s.FilePath, fm.Bytes(), 0644)
+}
 
-	// Marshal back to YAML
-	newFrontmatter, err := yaml.Marshal(&fm)
+// UpdateStatus updates the status field in a SEP file
+func (s *SEP) UpdateStatus(newStatus string) error {
+	err := s.EditFrontmatter(func(fm *FrontmatterEditor) error {
+		return fm.Set("status", newStatus)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal frontmatter: %w", err)
-	}
-
-	// Rebuild file content
-	newContent := "---\n" + string(newFrontmatter) + "---" + parts[2]
-
-	// Write back
-	if err := os.WriteFile(s.FilePath, []byte(newContent), 0644); err != nil {
 		return err
 	}
 
@@ -341,40 +357,13 @@ func (s *SEP) UpdateStatus(newStatus string) error {
 
 // Assign sets the assigned pilot for a SEP
 func (s *SEP) Assign(pilot string) error {
-	content, err := os.ReadFile(s.FilePath)
+	err := s.EditFrontmatter(func(fm *FrontmatterEditor) error {
+		return fm.Set("assigned", pilot)
+	})
 	if err != nil {
 		return err
 	}
 
-	// Extract frontmatter
-	parts := strings.SplitN(string(content), "---", 3)
-	if len(parts) < 3 {
-		return fmt.Errorf("invalid frontmatter format")
-	}
-
-	// Parse frontmatter
-	var fm Frontmatter
-	if err := yaml.Unmarshal([]byte(parts[1]), &fm); err != nil {
-		return fmt.Errorf("failed to parse frontmatter: %w", err)
-	}
-
-	// Update assigned
-	fm.Assigned = pilot
-
-	// Marshal back to YAML
-	newFrontmatter, err := yaml.Marshal(&fm)
-	if err != nil {
-		return fmt.Errorf("failed to marshal frontmatter: %w", err)
-	}
-
-	// Rebuild file content
-	newContent := "---\n" + string(newFrontmatter) + "---" + parts[2]
-
-	// Write back
-	if err := os.WriteFile(s.FilePath, []byte(newContent), 0644); err != nil {
-		return err
-	}
-
 	s.Assigned = pilot
 	return nil
 }
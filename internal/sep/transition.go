@@ -0,0 +1,153 @@
+package sep
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Guard is a precondition that must hold for a transition
+type Guard struct {
+	Name  string   // Short identifier shown in errors
+	To    []string // Statuses the guard applies to entering
+	Check func(s *SEP, all []*SEP) error
+}
+
+// Override records why a transition bypassed the state machine
+type Override struct {
+	By     string // Who forced the transition
+	Reason string // Why it was forced
+}
+
+// AllowedTransitions returns the statuses a SEP in status from may move to
+func AllowedTransitions(from string) []string {
+	return Transitions[from]
+}
+
+// CheckTransition verifies that s may move to the given status: the move
+// must be in the transition table, the user must hold a role allowed to
+// enter the status (see SetRoles) and every applicable guard must pass.
+// all is the full SEP list, used to resolve dependencies.
+func CheckTransition(s *SEP, to string, all []*SEP) error {
+	if !IsValidStatus(to) {
+		return fmt.Errorf("invalid status: %s\nValid statuses: %s", to, strings.Join(ValidStatuses, ", "))
+	}
+	if s.Status == to {
+		return fmt.Errorf("%s is already %s", s.ID(), to)
+	}
+
+	// SEPs with an unknown status may be moved anywhere to repair them
+	allowed, known := Transitions[s.Status]
+	if known && !contains(allowed, to) {
+		if len(allowed) == 0 {
+			return fmt.Errorf("%s cannot leave %s", s.ID(), s.Status)
+		}
+		return fmt.Errorf("%s cannot move from %s to %s (allowed: %s)",
+			s.ID(), s.Status, to, strings.Join(allowed, ", "))
+	}
+
+	var failures []string
+	if err := CheckRole(actor, to); err != nil {
+		failures = append(failures, fmt.Sprintf("role: %v", err))
+	}
+	for _, g := range Guards {
+		if !contains(g.To, to) {
+			continue
+		}
+		if err := g.Check(s, all); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", g.Name, err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s cannot move from %s to %s:\n  - %s",
+			s.ID(), s.Status, to, strings.Join(failures, "\n  - "))
+	}
+
+	return nil
+}
+
+// Transition moves the SEP to newStatus after CheckTransition passes.
+// A non-nil override skips the checks and is recorded in the
+// forced_transitions frontmatter field.
+func (s *SEP) Transition(newStatus string, all []*SEP, override *Override) error {
+	if override == nil {
+		if err := CheckTransition(s, newStatus, all); err != nil {
+			return err
+		}
+		return s.UpdateStatus(newStatus)
+	}
+
+	record := fmt.Sprintf("%s: %s → %s", time.Now().Format("2006-01-02"), s.Status, newStatus)
+	if override.By != "" {
+		record += " by " + override.By
+	}
+	if override.Reason != "" {
+		record += " (" + override.Reason + ")"
+	}
+
+	err := s.EditFrontmatter(func(fm *FrontmatterEditor) error {
+		var forced []string
+		if _, err := fm.Get("forced_transitions", &forced); err != nil {
+			return err
+		}
+		if err := fm.Set("status", newStatus); err != nil {
+			return err
+		}
+		return fm.Set("forced_transitions", append(forced, record))
+	})
+	if err != nil {
+		return err
+	}
+
+	s.Status = newStatus
+	s.ForcedTransitions = append(s.ForcedTransitions, record)
+	return nil
+}
+
+// criteriaComplete requires every Done When criterion to be checked
+func criteriaComplete(s *SEP, all []*SEP) error {
+	if len(s.DoneWhen) == 0 {
+		return fmt.Errorf("no Done When criteria defined")
+	}
+
+	var open []string
+	for i, checked := range s.DoneWhenStatus {
+		if !checked {
+			open = append(open, fmt.Sprintf("#%d", i+1))
+		}
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("%d of %d Done When criteria unchecked (%s)",
+			len(open), len(s.DoneWhen), strings.Join(open, ", "))
+	}
+	return nil
+}
+
+// dependenciesDone requires every direct and transitive dependency to be
+// done (see Workflow.Done)
+func dependenciesDone(s *SEP, all []*SEP) error {
+	graph := NewGraph(append([]*SEP{s}, all...))
+
+	var pending []string
+	for _, dep := range graph.Blockers(s.Number) {
+		if blocker := graph.Node(dep); blocker != nil {
+			pending = append(pending, fmt.Sprintf("SEP-%s (%s)", dep, blocker.Status))
+		} else {
+			pending = append(pending, fmt.Sprintf("SEP-%s (not found)", dep))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("dependencies not %s: %s", DoneStatus, strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// contains reports whether list contains s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package sep
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultVerifyTimeout bounds each verify command unless configured
+const DefaultVerifyTimeout = 5 * time.Minute
+
+// Verifier runs the verify commands of Done When criteria
+type Verifier struct {
+	Dir     string        // working directory of the commands; empty for the current one
+	Timeout time.Duration // limit per command; 0 for DefaultVerifyTimeout
+}
+
+// verifier is used by the criteria-verified guard
+var verifier = Verifier{}
+
+// SetVerifier configures how the criteria-verified guard runs commands
+func SetVerifier(v Verifier) {
+	verifier = v
+}
+
+// Verification is the outcome of one verify command
+type Verification struct {
+	Number    int // 1-based criterion number
+	Criterion Criterion
+	Passed    bool
+	TimedOut  bool
+	Output    string // combined stdout and stderr
+	Err       error  // why the command failed, nil if it passed
+	Duration  time.Duration
+}
+
+// Verify runs the verify command of each criterion of s that has one.
+// numbers limits it to those criteria (1-based); empty means all.
+func (v Verifier) Verify(s *SEP, numbers []int) ([]Verification, error) {
+	criteria := s.Criteria()
+	if len(numbers) == 0 {
+		for i := range criteria {
+			numbers = append(numbers, i+1)
+		}
+	}
+
+	var results []Verification
+	for _, n := range numbers {
+		c, err := criterionAt(criteria, n)
+		if err != nil {
+			return nil, err
+		}
+		if c.Verify == "" {
+			continue
+		}
+		results = append(results, v.run(n, c))
+	}
+	return results, nil
+}
+
+// run executes the verify command of criterion n through the shell
+func (v Verifier) run(n int, c Criterion) Verification {
+	timeout := v.Timeout
+	if timeout <= 0 {
+		timeout = DefaultVerifyTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.Verify)
+	cmd.Dir = v.Dir
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	// Don't wait for children still holding the output open after a kill
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	err := cmd.Run()
+	result := Verification{
+		Number:    n,
+		Criterion: c,
+		Passed:    err == nil,
+		Output:    strings.TrimRight(out.String(), "\n"),
+		Duration:  time.Since(start),
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.Passed, result.TimedOut = false, true
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	result.Err = err
+	return result
+}
+
+// criteriaVerified requires every verify command of s to pass
+func criteriaVerified(s *SEP, all []*SEP) error {
+	results, err := verifier.Verify(s, nil)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, r := range results {
+		if !r.Passed {
+			failed = append(failed, fmt.Sprintf("#%d (%v)", r.Number, r.Err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d verify commands failed: %s",
+			len(failed), len(results), strings.Join(failed, ", "))
+	}
+	return nil
+}
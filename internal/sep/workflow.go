@@ -0,0 +1,397 @@
+package sep
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// StatusDef describes one status of the SEP lifecycle
+type StatusDef struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"` // Shown next to the status group, e.g. "awaiting review"
+	Active      bool     `yaml:"active"`                // Considered for area conflict detection
+	Terminal    bool     `yaml:"terminal,omitempty"`    // Finished for good
+	InFlight    bool     `yaml:"in_flight,omitempty"`   // Being implemented or reviewed
+	Transitions []string `yaml:"transitions"`           // Statuses this status may move to
+	Guards      []string `yaml:"guards,omitempty"`      // Guards checked when entering this status
+	Color       string   `yaml:"color,omitempty"`       // Node fill color in 'vibe sep graph', e.g. "#a5d6a7"
+
+	// Next is a text/template rendered with the *SEP to recommend the next
+	// action for SEPs in this status, e.g. "Review SEP-{{.Number}}"
+	Next string `yaml:"next,omitempty"`
+	// NextNeedsDependencies only recommends SEPs whose dependencies are done
+	NextNeedsDependencies bool `yaml:"next_needs_dependencies,omitempty"`
+}
+
+// Workflow defines the statuses of the SEP lifecycle and how they are used
+type Workflow struct {
+	Statuses  []StatusDef `yaml:"statuses"`            // All statuses, in lifecycle order
+	Order     []string    `yaml:"order,omitempty"`     // Display order of status groups
+	Recommend []string    `yaml:"recommend,omitempty"` // Statuses checked for a next action, by priority
+	IdleNext  string      `yaml:"idle_next,omitempty"` // Next action when no status recommends one
+
+	// Done is the terminal status of successfully finished SEPs, which
+	// dependencies must reach. Defaults to DONE if the workflow has it,
+	// else its first terminal status.
+	Done string `yaml:"done,omitempty"`
+}
+
+// RequireGuard adds the named guard to status unless it already has it. It
+// reports false if the workflow has no such status.
+func (w *Workflow) RequireGuard(status, guard string) bool {
+	for i, def := range w.Statuses {
+		if def.Name != status {
+			continue
+		}
+		if !contains(def.Guards, guard) {
+			w.Statuses[i].Guards = append(append([]string{}, def.Guards...), guard)
+		}
+		return true
+	}
+	return false
+}
+
+// Derived from the current workflow by SetWorkflow
+var (
+	// ValidStatuses lists all valid SEP statuses
+	ValidStatuses []string
+
+	// DisplayOrder is the order in which status groups are shown
+	DisplayOrder []string
+
+	// Transitions lists the statuses each status may move to
+	Transitions map[string][]string
+
+	// Guards are checked for every transition they apply to
+	Guards []Guard
+
+	// DoneStatus is the status dependencies must reach, see Workflow.Done
+	DoneStatus string
+
+	workflow    Workflow
+	statusIndex map[string]StatusDef
+)
+
+// guardChecks maps guard names usable in a workflow to their checks
+var guardChecks = map[string]func(s *SEP, all []*SEP) error{
+	"criteria-complete": criteriaComplete,
+	"dependencies-done": dependenciesDone,
+	"criteria-verified": criteriaVerified,
+}
+
+// guardDescriptions explain the guards in help text
+var guardDescriptions = map[string]string{
+	"criteria-complete": "every Done When criterion is checked",
+	"dependencies-done": "every depends_on SEP, directly or transitively, is %s",
+	"criteria-verified": "every verify command of the Done When criteria passes",
+}
+
+// GuardDescription explains what the named guard requires
+func GuardDescription(name string) string {
+	if desc := guardDescriptions[name]; strings.Contains(desc, "%s") {
+		return fmt.Sprintf(desc, DoneStatus)
+	}
+	return guardDescriptions[name]
+}
+
+// guardNames fixes the order in which guards are checked and reported
+var guardNames = []string{"criteria-complete", "dependencies-done", "criteria-verified"}
+
+func init() {
+	if err := SetWorkflow(DefaultWorkflow()); err != nil {
+		panic(err)
+	}
+}
+
+// DefaultWorkflow returns the built-in SEP lifecycle:
+// DRAFT → ACCEPTED → IN_PROGRESS → IN_REVIEW → DONE, with BLOCKED and
+// CANCELLED reachable from any open status
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		Statuses: []StatusDef{
+			{
+				Name:        StatusDraft,
+				Color:       "#e0e0e0",
+				Description: "awaiting review",
+				Active:      true,
+				Transitions: []string{StatusAccepted, StatusBlocked, StatusCancelled},
+				Next:        "Review SEP-{{.Number}} (editor approval needed before implementation)",
+			},
+			{
+				Name:                  StatusAccepted,
+				Color:                 "#90caf9",
+				Description:           "ready for implementation",
+				Active:                true,
+				Transitions:           []string{StatusInProgress, StatusDone, StatusDraft, StatusBlocked, StatusCancelled},
+				Next:                  "Implement SEP-{{.Number}} with /sep-plan then /sep-implement",
+				NextNeedsDependencies: true,
+			},
+			{
+				Name:        StatusInProgress,
+				Color:       "#ffe082",
+				Description: "being implemented",
+				Active:      true,
+				InFlight:    true,
+				Transitions: []string{StatusInReview, StatusDone, StatusAccepted, StatusBlocked, StatusCancelled},
+				Guards:      []string{"dependencies-done"},
+				Next:        "SEPs are being implemented; create a new SEP or help review",
+			},
+			{
+				Name:        StatusInReview,
+				Color:       "#ffcc80",
+				Description: "awaiting code review",
+				Active:      true,
+				InFlight:    true,
+				Transitions: []string{StatusDone, StatusInProgress, StatusBlocked, StatusCancelled},
+				Guards:      []string{"dependencies-done"},
+				Next:        "Review the implementation of SEP-{{.Number}}, then 'vibe sep update {{.Number}} DONE'",
+			},
+			{
+				Name:        StatusBlocked,
+				Color:       "#ef9a9a",
+				Active:      true,
+				Transitions: []string{StatusDraft, StatusAccepted, StatusInProgress, StatusCancelled},
+				Next:        "Resolve blocked SEPs to continue",
+			},
+			{
+				Name:        StatusCancelled,
+				Color:       "#bdbdbd",
+				Terminal:    true,
+				Transitions: []string{StatusDraft},
+			},
+			{
+				Name:        StatusDone,
+				Color:       "#a5d6a7",
+				Terminal:    true,
+				Transitions: []string{},
+				Guards:      []string{"criteria-complete", "dependencies-done"},
+			},
+		},
+		Order: []string{
+			StatusInReview,
+			StatusInProgress,
+			StatusAccepted,
+			StatusDraft,
+			StatusBlocked,
+			StatusDone,
+			StatusCancelled,
+		},
+		Recommend: []string{
+			StatusInReview,
+			StatusAccepted,
+			StatusDraft,
+			StatusBlocked,
+			StatusInProgress,
+		},
+		IdleNext: `Create a new SEP with 'vibe sep new "Feature Name"'`,
+	}
+}
+
+// SetWorkflow validates w and makes it the current workflow.
+//
+// Statuses missing from Order are shown last; unknown statuses in Order
+// and Recommend are ignored. Without Recommend, statuses with a Next
+// template are checked in lifecycle order.
+func SetWorkflow(w Workflow) error {
+	if len(w.Statuses) == 0 {
+		return fmt.Errorf("workflow defines no statuses")
+	}
+
+	index := make(map[string]StatusDef)
+	for _, def := range w.Statuses {
+		if def.Name == "" {
+			return fmt.Errorf("workflow status without a name")
+		}
+		if _, dup := index[def.Name]; dup {
+			return fmt.Errorf("workflow status %s is defined twice", def.Name)
+		}
+		index[def.Name] = def
+	}
+
+	valid := make([]string, 0, len(w.Statuses))
+	transitions := make(map[string][]string)
+	guardTargets := make(map[string][]string)
+	for _, def := range w.Statuses {
+		valid = append(valid, def.Name)
+		for _, to := range def.Transitions {
+			if _, ok := index[to]; !ok {
+				return fmt.Errorf("workflow status %s transitions to unknown status %s", def.Name, to)
+			}
+		}
+		transitions[def.Name] = def.Transitions
+		for _, name := range def.Guards {
+			if _, ok := guardChecks[name]; !ok {
+				return fmt.Errorf("workflow status %s uses unknown guard %q (available: %s)",
+					def.Name, name, strings.Join(guardNames, ", "))
+			}
+			guardTargets[name] = append(guardTargets[name], def.Name)
+		}
+		if def.Next != "" {
+			if _, err := template.New(def.Name).Parse(def.Next); err != nil {
+				return fmt.Errorf("workflow status %s has an invalid next template: %w", def.Name, err)
+			}
+		}
+	}
+
+	done := w.FinalStatus()
+	if w.Done != "" {
+		if def, ok := index[w.Done]; !ok || !def.Terminal {
+			return fmt.Errorf("workflow done status %s must be a terminal status", w.Done)
+		}
+	}
+
+	var guards []Guard
+	for _, name := range guardNames {
+		if targets := guardTargets[name]; len(targets) > 0 {
+			guards = append(guards, Guard{Name: name, To: targets, Check: guardChecks[name]})
+		}
+	}
+
+	order := knownStatuses(w.Order, index)
+	for _, name := range valid {
+		if !contains(order, name) {
+			order = append(order, name)
+		}
+	}
+	w.Order = order
+	w.Recommend = knownStatuses(w.Recommend, index)
+	if len(w.Recommend) == 0 {
+		for _, def := range w.Statuses {
+			if def.Next != "" {
+				w.Recommend = append(w.Recommend, def.Name)
+			}
+		}
+	}
+
+	workflow = w
+	statusIndex = index
+	ValidStatuses = valid
+	DisplayOrder = order
+	Transitions = transitions
+	Guards = guards
+	DoneStatus = done
+	return nil
+}
+
+// FinalStatus returns the done status of w: Done if set, DONE if w has
+// it, else its first terminal status
+func (w Workflow) FinalStatus() string {
+	if w.Done != "" {
+		return w.Done
+	}
+	var first string
+	for _, def := range w.Statuses {
+		if def.Name == StatusDone {
+			return def.Name
+		}
+		if def.Terminal && first == "" {
+			first = def.Name
+		}
+	}
+	return first
+}
+
+// CurrentWorkflow returns the workflow in effect
+func CurrentWorkflow() Workflow {
+	return workflow
+}
+
+// LookupStatus returns the definition of a status in the current workflow
+func LookupStatus(name string) (StatusDef, bool) {
+	def, ok := statusIndex[name]
+	return def, ok
+}
+
+// IsDone reports whether a SEP in this status is successfully finished, so
+// SEPs depending on it may proceed
+func IsDone(status string) bool {
+	return status != "" && status == DoneStatus
+}
+
+// IsTerminal reports whether a SEP in this status is finished for good
+func IsTerminal(status string) bool {
+	return statusIndex[status].Terminal
+}
+
+// IsInFlight reports whether a pilot is actively working on the SEP or it
+// is awaiting code review
+func IsInFlight(status string) bool {
+	return statusIndex[status].InFlight
+}
+
+// IsActive reports whether SEPs in this status take part in area conflict
+// detection. Unknown statuses are treated as active.
+func IsActive(status string) bool {
+	def, ok := statusIndex[status]
+	return !ok || def.Active
+}
+
+// Recommendation is the suggested next action and the SEP it is about, if
+// any
+type Recommendation struct {
+	SEP    *SEP // nil when there is nothing to pick up
+	Action string
+}
+
+// Recommend returns the next action for the given SEPs, following the
+// workflow's recommendation priority
+func Recommend(seps []*SEP) string {
+	return NextAction(seps).Action
+}
+
+// NextAction is like Recommend but also returns the SEP the action is about
+func NextAction(seps []*SEP) Recommendation {
+	graph := NewGraph(seps)
+
+	// Prefer SEPs that others build on: walk them in implementation order
+	ordered := seps
+	if order, err := graph.TopologicalOrder(); err == nil {
+		ordered = make([]*SEP, 0, len(order))
+		for _, number := range order {
+			ordered = append(ordered, graph.Node(number))
+		}
+	}
+	groups := GroupByStatus(ordered)
+
+	for _, status := range workflow.Recommend {
+		def := statusIndex[status]
+		if def.Next == "" {
+			continue
+		}
+		for _, s := range groups[status] {
+			if def.NextNeedsDependencies && len(graph.Blockers(s.Number)) > 0 {
+				continue
+			}
+			if next, err := renderNext(def, s); err == nil {
+				return Recommendation{SEP: s, Action: next}
+			}
+		}
+	}
+
+	return Recommendation{Action: workflow.IdleNext}
+}
+
+// renderNext renders the next-action template of a status for s
+func renderNext(def StatusDef, s *SEP) (string, error) {
+	tmpl, err := template.New(def.Name).Parse(def.Next)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, s); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// knownStatuses filters names down to statuses defined in index
+func knownStatuses(names []string, index map[string]StatusDef) []string {
+	var known []string
+	for _, name := range names {
+		if _, ok := index[name]; ok && !contains(known, name) {
+			known = append(known, name)
+		}
+	}
+	return known
+}